@@ -36,6 +36,7 @@ import (
 	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/role"
 	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/role_sharing_policy"
 	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/service"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/template_render"
 	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/user"
 	"github.com/philips-software/terraform-provider-hsdp/internal/services/metrics"
 	"github.com/philips-software/terraform-provider-hsdp/internal/services/notification"
@@ -251,6 +252,7 @@ func Provider(build string) *schema.Provider {
 			"hsdp_iam_mfa_policy":                            iam.ResourceIAMMFAPolicy(),
 			"hsdp_iam_password_policy":                       iam.ResourceIAMPasswordPolicy(),
 			"hsdp_iam_email_template":                        email_template.ResourceIAMEmailTemplate(),
+			"hsdp_iam_email_template_set":                    email_template.ResourceIAMEmailTemplateSet(),
 			"hsdp_container_host":                            ch.ResourceContainerHost(),
 			"hsdp_metrics_autoscaler":                        metrics.ResourceMetricsAutoscaler(),
 			"hsdp_pki_tenant":                                pki_tenant.ResourcePKITenant(),
@@ -286,10 +288,14 @@ func Provider(build string) *schema.Provider {
 			"hsdp_connect_mdm_application":                   mdm.ResourceMDMApplication(),
 			"hsdp_connect_mdm_firmware_component_version":    mdm.ResourceConnectMDMFirmwareComponentVersion(),
 			"hsdp_connect_mdm_firmware_distribution_request": mdm.ResourceConnectMDMFirmwareDistributionRequest(),
+			"hsdp_connect_mdm_firmware_rollout":              mdm.ResourceConnectMDMFirmwareRollout(),
+			"hsdp_connect_mdm_blueprint":                     mdm.ResourceConnectMDMBlueprint(),
 			"hsdp_connect_iot_provisioning_orgconfiguration": provisioning.ResourceConnectIoTProvisioningOrgConfiguration(),
 			"hsdp_iam_group_membership":                      group_membership.ResourceIAMGroupMembership(),
 			"hsdp_iam_role_sharing_policy":                   role_sharing_policy.ResourceRoleSharingPolicy(),
+			"hsdp_iam_role_sharing_policy_set":               role_sharing_policy.ResourceRoleSharingPolicySet(),
 			"hsdp_iam_device":                                device.ResourceIAMDevice(),
+			"hsdp_iam_device_batch":                          device.ResourceIAMDeviceBatch(),
 			"hsdp_blr_bucket":                                blr.ResourceBLRBucket(),
 			"hsdp_blr_blob_store_policy":                     blr.ResourceBLRBlobStorePolicy(),
 			"hsdp_dbs_sqs_subscriber":                        dbs.ResourceDBSSQSSubscriber(),
@@ -302,6 +308,7 @@ func Provider(build string) *schema.Provider {
 			"hsdp_iam_service":                               service.DataSourceService(),
 			"hsdp_iam_permissions":                           iam.DataSourceIAMPermissions(),
 			"hsdp_iam_org":                                   organization.DataSourceIAMOrg(),
+			"hsdp_iam_org_hierarchy":                         organization.DataSourceIAMOrgHierarchy(),
 			"hsdp_iam_proposition":                           proposition.DataSourceIAMProposition(),
 			"hsdp_iam_application":                           application.DataSourceIAMApplication(),
 			"hsdp_config":                                    configuration.DataSourceConfig(),
@@ -338,19 +345,41 @@ func Provider(build string) *schema.Provider {
 			"hsdp_connect_mdm_data_adapters":                 mdm.DataSourceConnectMDMDataAdapters(),
 			"hsdp_connect_iot_provisioning_orgconfiguration": provisioning.DataSourceConnectIoTProvisioningOrgConfiguration(),
 			"hsdp_iam_email_templates":                       email_template.DataSourceIAMEmailTemplates(),
+			"hsdp_iam_template_render":                       template_render.DataSourceIAMTemplateRender(),
 			"hsdp_connect_mdm_bucket":                        mdm.DataSourceConnectMDMBucket(),
 			"hsdp_connect_mdm_data_type":                     mdm.DataSourceConnectMDMDataType(),
 			"hsdp_container_host_security_groups":            ch.DataSourceContainerHostSecurityGroups(),
 			"hsdp_container_host_security_group_details":     ch.DataSourceContainerHostSecurityGroupDetails(),
 			"hsdp_iam_token":                                 iam.DataSourceIAMToken(),
+			"hsdp_iam_access_token":                          iam.DataSourceIAMAccessToken(),
 			"hsdp_connect_mdm_service_agent":                 mdm.DataSourceConnectMDMServiceAgent(),
 			"hsdp_connect_mdm_service_agents":                mdm.DataSourceConnectMDMServiceAgents(),
 			"hsdp_container_host":                            ch.DataSourceContainerHost(),
 			"hsdp_iam_permission":                            iam.DataSourceIAMPermission(),
+			"hsdp_iam_password_policy":                       iam.DataSourceIAMPasswordPolicy(),
+			"hsdp_iam_mfa_policy":                            iam.DataSourceIAMMFAPolicy(),
 			"hsdp_iam_role_sharing_policies":                 role_sharing_policy.DataSourceIAMRoleSharingPolicies(),
 			"hsdp_discovery_service":                         discovery.DataSourceDiscoveryService(),
 			"hsdp_connect_mdm_service_action":                mdm.DataSourceConnectMDMServiceAction(),
 			"hsdp_connect_mdm_service_actions":               mdm.DataSourceConnectMDMServiceActions(),
+			"hsdp_connect_mdm_device_group":                  mdm.DataSourceConnectMDMDeviceGroup(),
+			"hsdp_connect_mdm_device_groups":                 mdm.DataSourceConnectMDMDeviceGroups(),
+			"hsdp_connect_mdm_device_type":                   mdm.DataSourceConnectMDMDeviceType(),
+			"hsdp_connect_mdm_device_type_attributes":        mdm.DataSourceConnectMDMDeviceTypeAttributes(),
+			"hsdp_connect_mdm_device_types":                  mdm.DataSourceConnectMDMDeviceTypes(),
+			"hsdp_connect_mdm_firmware_component":            mdm.DataSourceConnectMDMFirmwareComponent(),
+			"hsdp_connect_mdm_firmware_components":           mdm.DataSourceConnectMDMFirmwareComponents(),
+			"hsdp_connect_mdm_firmware_component_version":    mdm.DataSourceConnectMDMFirmwareComponentVersion(),
+			"hsdp_connect_mdm_firmware_component_versions":   mdm.DataSourceConnectMDMFirmwareComponentVersions(),
+			"hsdp_connect_mdm_oauth_client":                  mdm.DataSourceConnectMDMOAuthClient(),
+			"hsdp_connect_mdm_oauth_clients":                 mdm.DataSourceConnectMDMOAuthClients(),
+			"hsdp_connect_mdm_authentication_method":         mdm.DataSourceConnectMDMAuthenticationMethod(),
+			"hsdp_connect_mdm_authentication_methods":        mdm.DataSourceConnectMDMAuthenticationMethods(),
+			"hsdp_connect_mdm_blob_data_contract":            mdm.DataSourceConnectMDMBlobDataContract(),
+			"hsdp_connect_mdm_blob_data_contracts":           mdm.DataSourceConnectMDMBlobDataContracts(),
+			"hsdp_connect_mdm_blob_subscription":             mdm.DataSourceConnectMDMBlobSubscription(),
+			"hsdp_connect_mdm_blob_subscriptions":            mdm.DataSourceConnectMDMBlobSubscriptions(),
+			"hsdp_connect_mdm_export":                        mdm.DataSourceConnectMDMExport(),
 			"hsdp_blr_store_policy":                          blr.DataSourceBLRBlobStorePolicyDefinition(),
 		},
 		ConfigureContextFunc: providerConfigure(build),
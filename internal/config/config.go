@@ -111,6 +111,9 @@ func (c *Config) IAMClient(principal ...*Principal) (*iam.Client, error) {
 		if p.Region != "" {
 			cfg.Region = p.Region
 		}
+		if len(p.Scopes) > 0 {
+			cfg.Scopes = p.Scopes
+		}
 		iamClient, err := iam.NewClient(nil, &cfg)
 		if err != nil {
 			return nil, err
@@ -221,7 +224,36 @@ func (c *Config) ConsoleClient(principal ...*Principal) (*console.Client, error)
 	return client, err
 }
 
-func (c *Config) MDMClient() (*mdm.Client, error) {
+func (c *Config) MDMClient(principal ...*Principal) (*mdm.Client, error) {
+	if len(principal) > 0 && principal[0] != nil && principal[0].HasAuth() {
+		region := principal[0].Region
+		environment := principal[0].Environment
+		iamClient, err := c.IAMClient(principal...)
+		if err != nil {
+			return nil, err
+		}
+		endpoint := principal[0].Endpoint
+		if endpoint == "" && region == c.Region && environment == c.Environment {
+			endpoint = c.MDMURL
+		}
+		if endpoint == "" {
+			env := environment
+			if env == "" {
+				env = "prod"
+			}
+			ac, err := config.New(config.WithRegion(region), config.WithEnv(env))
+			if err == nil {
+				endpoint = ac.Service("connect-mdm").URL
+			}
+			if endpoint == "" {
+				return nil, fmt.Errorf("missing MDM URL (%s/%s), you can set a custom value using the principal 'endpoint'", env, region)
+			}
+		}
+		return mdm.NewClient(iamClient, &mdm.Config{
+			BaseURL:  endpoint,
+			DebugLog: c.DebugWriter,
+		})
+	}
 	return c.mdmClient, c.mdmClientErr
 }
 
@@ -13,6 +13,7 @@ var (
 	ErrDeleteRoleFailed          = errors.New("delete role failed")
 	ErrDeleteMFAPolicyFailed     = errors.New("delete of MFA policy failed")
 	ErrDeleteEmailTemplateFailed = errors.New("delete email template failed")
+	ErrEmailTemplateConflict     = errors.New("email template with the same type and locale exists")
 	ErrDeleteClientFailed        = errors.New("delete client failed")
 	ErrDeleteServiceFailed       = errors.New("delete service failed")
 	ErrDeleteSubscriptionFailed  = errors.New("delete subscription failed")
@@ -17,6 +17,8 @@ type Principal struct {
 	Endpoint          string
 	UAAUsername       string
 	UAAPassword       string
+	// Scopes are requested when logging in as this principal. Not part of PrincipalSchema
+	Scopes []string
 }
 
 func PrincipalSchema() *schema.Schema {
@@ -0,0 +1,106 @@
+package mdm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+)
+
+const authMethodCheckTimeout = 30 * time.Second
+
+// writeOnlyString returns the configured value of a write-only attribute. Write-only values are only
+// available in the configuration during plan and apply, so this returns an empty string during read
+func writeOnlyString(d *schema.ResourceData, key string) string {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() || !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+	return v.AsString()
+}
+
+type authMethodChecker struct {
+	httpClient *http.Client
+}
+
+func newAuthMethodChecker() *authMethodChecker {
+	return &authMethodChecker{
+		httpClient: &http.Client{Timeout: authMethodCheckTimeout},
+	}
+}
+
+// checkTokenRequest requests a token from auth_url with the credentials of the authentication method. The
+// password grant is used when a login name and password are set, the client credentials grant otherwise
+func (c *authMethodChecker) checkTokenRequest(ctx context.Context, am mdm.AuthenticationMethod) error {
+	if am.AuthURL == "" {
+		return fmt.Errorf("auth_url is not set")
+	}
+	form := url.Values{}
+	if am.LoginName != "" && am.Password != "" {
+		form.Set("grant_type", "password")
+		form.Set("username", am.LoginName)
+		form.Set("password", am.Password)
+	} else {
+		form.Set("grant_type", "client_credentials")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, am.AuthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(am.ClientID, am.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("contacting '%s': %w", am.AuthURL, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		var tokenError struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&tokenError)
+		if tokenError.Description != "" {
+			return fmt.Errorf("'%s' rejected the credentials: %s", am.AuthURL, tokenError.Description)
+		}
+		if tokenError.Error != "" {
+			return fmt.Errorf("'%s' rejected the credentials: %s", am.AuthURL, tokenError.Error)
+		}
+		return fmt.Errorf("'%s' rejected the credentials with status %d", am.AuthURL, resp.StatusCode)
+	default:
+		return fmt.Errorf("'%s' returned unexpected status %d", am.AuthURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// checkAuthenticationMethod verifies the authentication method can obtain a token when check_connectivity
+// is set. Failures are reported as warnings, as the remote service may be down temporarily
+func checkAuthenticationMethod(ctx context.Context, d *schema.ResourceData, am mdm.AuthenticationMethod) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !d.Get("check_connectivity").(bool) {
+		_ = d.Set("connectivity_status", "")
+		return diags
+	}
+	if err := newAuthMethodChecker().checkTokenRequest(ctx, am); err != nil {
+		_ = d.Set("connectivity_status", err.Error())
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s connectivity check failed", d.Id()),
+			Detail:   err.Error(),
+		})
+	}
+	_ = d.Set("connectivity_status", "ok")
+	return diags
+}
@@ -0,0 +1,101 @@
+package mdm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMethodCheckerTokenRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		switch r.Form.Get("grant_type") {
+		case "client_credentials":
+		case "password":
+			if r.Form.Get("username") != "user" || r.Form.Get("password") != "pass" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"Bad credentials"}`))
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"token"}`))
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	checker := newAuthMethodChecker()
+	ctx := context.Background()
+	am := mdm.AuthenticationMethod{
+		AuthURL:      server.URL + "/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+	}
+	assert.Nil(t, checker.checkTokenRequest(ctx, am))
+
+	withPassword := am
+	withPassword.LoginName = "user"
+	withPassword.Password = "pass"
+	assert.Nil(t, checker.checkTokenRequest(ctx, withPassword))
+
+	withPassword.Password = "wrong"
+	err := checker.checkTokenRequest(ctx, withPassword)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "Bad credentials")
+	}
+
+	wrongSecret := am
+	wrongSecret.ClientSecret = "wrong"
+	err = checker.checkTokenRequest(ctx, wrongSecret)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "invalid_client")
+	}
+
+	broken := am
+	broken.AuthURL = server.URL + "/broken"
+	err = checker.checkTokenRequest(ctx, broken)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "unexpected status 500")
+	}
+
+	missing := am
+	missing.AuthURL = ""
+	assert.NotNil(t, checker.checkTokenRequest(ctx, missing))
+}
+
+func TestWriteOnlySecretFields(t *testing.T) {
+	r := ResourceConnectMDMAuthenticationMethod()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"password": "pass",
+	})
+	assert.Empty(t, writeOnlySecretFields(d))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"password":           "pass",
+		"secrets_wo_version": 1,
+	})
+	assert.Equal(t, []string{"client_secret"}, writeOnlySecretFields(d))
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"secrets_wo_version": 2,
+	})
+	assert.Equal(t, []string{"password", "client_secret"}, writeOnlySecretFields(d))
+}
@@ -0,0 +1,359 @@
+package mdm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// blueprintKinds lists the MDM objects a blueprint can contain. The rank orders
+// objects without references between them, parents first
+var blueprintKinds = map[string]struct {
+	rank     int
+	resource func() *schema.Resource
+}{
+	"proposition":           {0, ResourceMDMProposition},
+	"application":           {1, ResourceMDMApplication},
+	"device_group":          {2, ResourceConnectMDMDeviceGroup},
+	"device_type":           {3, ResourceConnectMDMDeviceType},
+	"standard_service":      {4, ResourceConnectMDMStandardService},
+	"service_action":        {5, ResourceConnectMDMServiceAction},
+	"service_reference":     {6, ResourceConnectMDMServiceReference},
+	"oauth_client":          {7, ResourceConnectMDMOAuthClient},
+	"authentication_method": {8, ResourceConnectMDMAuthenticationMethod},
+	"bucket":                {9, ResourceConnectMDMBucket},
+	"data_type":             {10, ResourceConnectMDMDataType},
+	"blob_data_contract":    {11, ResourceConnectMDMBlobDataContract},
+	"blob_subscription":     {12, ResourceConnectMDMBlobSubscription},
+	"firmware_component":    {13, ResourceConnectMDMFirmwareComponent},
+}
+
+// blueprintRef matches references like ${application.main.id}
+var blueprintRef = regexp.MustCompile(`\$\{([a-z_]+)\.([A-Za-z0-9_-]+)\.([a-z0-9_]+)\}`)
+
+// blueprintObject is a single MDM object of a blueprint spec
+type blueprintObject struct {
+	Kind       string
+	Name       string
+	Attributes map[string]interface{}
+}
+
+func (o blueprintObject) key() string {
+	return o.Kind + "." + o.Name
+}
+
+// parseBlueprint parses a spec of the form {"<kind>": {"<name>": {<attributes>}}}
+// and returns its objects in dependency order
+func parseBlueprint(spec string) ([]blueprintObject, error) {
+	var raw map[string]map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(spec), &raw); err != nil {
+		return nil, fmt.Errorf("invalid blueprint spec: %w", err)
+	}
+	var objects []blueprintObject
+	for kind, byName := range raw {
+		if _, ok := blueprintKinds[kind]; !ok {
+			return nil, fmt.Errorf("unsupported kind '%s' in blueprint spec", kind)
+		}
+		for name, attributes := range byName {
+			if _, ok := attributes["principal"]; ok {
+				return nil, fmt.Errorf("%s.%s: principal is set on the blueprint, not on its objects", kind, name)
+			}
+			objects = append(objects, blueprintObject{Kind: kind, Name: name, Attributes: attributes})
+		}
+	}
+	return orderBlueprint(objects)
+}
+
+// references returns the keys of the objects referenced by value
+func references(value interface{}) []string {
+	var refs []string
+	switch v := value.(type) {
+	case string:
+		for _, m := range blueprintRef.FindAllStringSubmatch(v, -1) {
+			refs = append(refs, m[1]+"."+m[2])
+		}
+	case []interface{}:
+		for _, e := range v {
+			refs = append(refs, references(e)...)
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			refs = append(refs, references(e)...)
+		}
+	}
+	return refs
+}
+
+// orderBlueprint sorts the objects so every object comes after the objects it references
+func orderBlueprint(objects []blueprintObject) ([]blueprintObject, error) {
+	sort.Slice(objects, func(i, j int) bool {
+		ri, rj := blueprintKinds[objects[i].Kind].rank, blueprintKinds[objects[j].Kind].rank
+		if ri != rj {
+			return ri < rj
+		}
+		return objects[i].Name < objects[j].Name
+	})
+	byKey := make(map[string]int)
+	for i, o := range objects {
+		byKey[o.key()] = i
+	}
+	deps := make([][]int, len(objects))
+	for i, o := range objects {
+		for _, ref := range references(o.Attributes) {
+			j, ok := byKey[ref]
+			if !ok {
+				return nil, fmt.Errorf("%s references unknown object '%s'", o.key(), ref)
+			}
+			if j == i {
+				return nil, fmt.Errorf("%s references itself", o.key())
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(objects))
+	var ordered []blueprintObject
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("blueprint spec has a reference cycle involving %s", objects[i].key())
+		}
+		state[i] = visiting
+		for _, j := range deps[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		state[i] = done
+		ordered = append(ordered, objects[i])
+		return nil
+	}
+	for i := range objects {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// resolveReferences replaces references in value using lookup
+func resolveReferences(value interface{}, lookup func(key, attribute string) (string, bool)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		var missing error
+		resolved := blueprintRef.ReplaceAllStringFunc(v, func(ref string) string {
+			m := blueprintRef.FindStringSubmatch(ref)
+			out, ok := lookup(m[1]+"."+m[2], m[3])
+			if !ok && missing == nil {
+				missing = fmt.Errorf("cannot resolve '%s'", ref)
+			}
+			return out
+		})
+		return resolved, missing
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			r, err := resolveReferences(e, lookup)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = r
+		}
+		return result, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			r, err := resolveReferences(e, lookup)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = r
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// hashAttributes returns a stable hash of resolved attributes
+func hashAttributes(attributes map[string]interface{}) string {
+	data, _ := json.Marshal(attributes) // Map keys are sorted by encoding/json
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// coerceValue converts a decoded JSON value to the type expected by s
+func coerceValue(s *schema.Schema, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch s.Type {
+	case schema.TypeString:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case schema.TypeInt:
+		switch v := value.(type) {
+		case float64:
+			if v != float64(int(v)) {
+				return nil, fmt.Errorf("expected an integer, got %v", v)
+			}
+			return int(v), nil
+		case string:
+			return strconv.Atoi(v)
+		}
+	case schema.TypeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case schema.TypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case schema.TypeList, schema.TypeSet:
+		items, ok := value.([]interface{})
+		if !ok {
+			// Allow a single block to be written as an object
+			if m, isMap := value.(map[string]interface{}); isMap {
+				items = []interface{}{m}
+			} else {
+				break
+			}
+		}
+		result := make([]interface{}, 0, len(items))
+		for i, item := range items {
+			var converted interface{}
+			var err error
+			switch elem := s.Elem.(type) {
+			case *schema.Schema:
+				converted, err = coerceValue(elem, item)
+			case *schema.Resource:
+				converted, err = coerceBlock(elem, item)
+			default:
+				converted = item
+			}
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			result = append(result, converted)
+		}
+		return result, nil
+	case schema.TypeMap:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			break
+		}
+		elem, _ := s.Elem.(*schema.Schema)
+		if elem == nil {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		result := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			converted, err := coerceValue(elem, v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			result[k] = converted
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("cannot use %T as %s", value, strings.TrimPrefix(s.Type.String(), "Type"))
+}
+
+func coerceBlock(r *schema.Resource, value interface{}) (interface{}, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %T", value)
+	}
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		s, ok := r.Schema[k]
+		if !ok {
+			return nil, fmt.Errorf("unsupported attribute '%s'", k)
+		}
+		converted, err := coerceValue(s, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		result[k] = converted
+	}
+	if err := applySchemaDefaults(r, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applySchemaDefaults adds the schema default of every attribute missing from values. Resource data
+// that is not created from a plan does not apply defaults by itself
+func applySchemaDefaults(r *schema.Resource, values map[string]interface{}) error {
+	for k, s := range r.Schema {
+		if _, ok := values[k]; ok {
+			continue
+		}
+		value, err := s.DefaultValue()
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		if value != nil {
+			values[k] = value
+		}
+	}
+	return nil
+}
+
+// validateBlueprintObject checks the attributes of an object against the schema of its resource
+func validateBlueprintObject(o blueprintObject) error {
+	r := blueprintKinds[o.Kind].resource()
+	for k, v := range o.Attributes {
+		s, ok := r.Schema[k]
+		if !ok || (s.Computed && !s.Optional) {
+			return fmt.Errorf("%s: unsupported attribute '%s'", o.key(), k)
+		}
+		if len(references(v)) > 0 {
+			continue // Checked once resolved
+		}
+		value, err := coerceValue(s, v)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", o.key(), k, err)
+		}
+		if s.ValidateFunc != nil && value != nil {
+			if _, errs := s.ValidateFunc(value, k); len(errs) > 0 {
+				return fmt.Errorf("%s.%s: %w", o.key(), k, errs[0])
+			}
+		}
+	}
+	for k, s := range r.Schema {
+		if s.Required {
+			if _, ok := o.Attributes[k]; !ok {
+				return fmt.Errorf("%s: missing required attribute '%s'", o.key(), k)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package mdm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func blueprintKeys(objects []blueprintObject) []string {
+	var keys []string
+	for _, o := range objects {
+		keys = append(keys, o.key())
+	}
+	return keys
+}
+
+func TestOrderBlueprint(t *testing.T) {
+	objects := []blueprintObject{
+		{Kind: "device_group", Name: "fleet", Attributes: map[string]interface{}{
+			"application_id": "${application.main.id}",
+		}},
+		{Kind: "application", Name: "main", Attributes: map[string]interface{}{
+			"proposition_id": "${proposition.main.id}",
+		}},
+		{Kind: "proposition", Name: "main", Attributes: map[string]interface{}{}},
+		{Kind: "bucket", Name: "b", Attributes: map[string]interface{}{}},
+		{Kind: "standard_service", Name: "svc", Attributes: map[string]interface{}{
+			"tags": []interface{}{"${bucket.b.id}"},
+		}},
+	}
+	ordered, err := orderBlueprint(objects)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{
+		"proposition.main",
+		"application.main",
+		"device_group.fleet",
+		"bucket.b",
+		"standard_service.svc",
+	}, blueprintKeys(ordered))
+}
+
+func TestOrderBlueprintErrors(t *testing.T) {
+	_, err := orderBlueprint([]blueprintObject{
+		{Kind: "application", Name: "a", Attributes: map[string]interface{}{"x": "${application.b.id}"}},
+		{Kind: "application", Name: "b", Attributes: map[string]interface{}{"x": "${application.a.id}"}},
+	})
+	assert.ErrorContains(t, err, "cycle")
+
+	_, err = orderBlueprint([]blueprintObject{
+		{Kind: "application", Name: "a", Attributes: map[string]interface{}{"x": "${proposition.missing.id}"}},
+	})
+	assert.ErrorContains(t, err, "unknown object 'proposition.missing'")
+
+	_, err = orderBlueprint([]blueprintObject{
+		{Kind: "application", Name: "a", Attributes: map[string]interface{}{"x": "${application.a.guid}"}},
+	})
+	assert.ErrorContains(t, err, "itself")
+}
+
+func TestParseBlueprintErrors(t *testing.T) {
+	_, err := parseBlueprint(`{"gizmo": {"a": {}}}`)
+	assert.ErrorContains(t, err, "unsupported kind 'gizmo'")
+
+	_, err = parseBlueprint(`{"proposition": {"a": {"principal": {}}}}`)
+	assert.ErrorContains(t, err, "principal")
+
+	_, err = parseBlueprint(`[]`)
+	assert.Error(t, err)
+}
+
+func TestResolveReferences(t *testing.T) {
+	lookup := func(key, attribute string) (string, bool) {
+		if key == "application.main" && attribute == "id" {
+			return "Application/1234", true
+		}
+		return "", false
+	}
+	resolved, err := resolveReferences(map[string]interface{}{
+		"application_id": "${application.main.id}",
+		"description":    "Devices of ${application.main.id}",
+		"tags":           []interface{}{"${application.main.id}", "plain"},
+		"count":          float64(3),
+	}, lookup)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]interface{}{
+		"application_id": "Application/1234",
+		"description":    "Devices of Application/1234",
+		"tags":           []interface{}{"Application/1234", "plain"},
+		"count":          float64(3),
+	}, resolved)
+
+	_, err = resolveReferences("${application.main.guid}", lookup)
+	assert.ErrorContains(t, err, "cannot resolve")
+}
+
+func TestHashAttributes(t *testing.T) {
+	a := hashAttributes(map[string]interface{}{"a": "1", "b": []interface{}{"x"}})
+	b := hashAttributes(map[string]interface{}{"b": []interface{}{"x"}, "a": "1"})
+	c := hashAttributes(map[string]interface{}{"a": "2", "b": []interface{}{"x"}})
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
+
+func TestCoerceValue(t *testing.T) {
+	v, err := coerceValue(&schema.Schema{Type: schema.TypeInt}, float64(42))
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	_, err = coerceValue(&schema.Schema{Type: schema.TypeInt}, 4.2)
+	assert.Error(t, err)
+
+	v, err = coerceValue(&schema.Schema{Type: schema.TypeString}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", v)
+
+	v, err = coerceValue(&schema.Schema{Type: schema.TypeBool}, "false")
+	assert.NoError(t, err)
+	assert.Equal(t, false, v)
+
+	_, err = coerceValue(&schema.Schema{Type: schema.TypeBool}, []interface{}{})
+	assert.ErrorContains(t, err, "cannot use []interface {} as Bool")
+
+	block := &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"algorithm": {Type: schema.TypeString},
+			"size":      {Type: schema.TypeInt},
+		}},
+	}
+	v, err = coerceValue(block, map[string]interface{}{"algorithm": "SHA-256", "size": float64(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"algorithm": "SHA-256", "size": 1}}, v)
+
+	_, err = coerceValue(block, []interface{}{map[string]interface{}{"bogus": "x"}})
+	assert.ErrorContains(t, err, "unsupported attribute 'bogus'")
+
+	v, err = coerceValue(&schema.Schema{Type: schema.TypeMap, Elem: &schema.Schema{Type: schema.TypeString}},
+		map[string]interface{}{"n": float64(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"n": "1"}, v)
+}
+
+func TestApplySchemaDefaults(t *testing.T) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{
+		"name":    {Type: schema.TypeString, Required: true},
+		"trusted": {Type: schema.TypeBool, Optional: true, Default: true},
+		"region":  {Type: schema.TypeString, Optional: true, DefaultFunc: func() (interface{}, error) { return "eu-west", nil }},
+		"note":    {Type: schema.TypeString, Optional: true},
+	}}
+	values := map[string]interface{}{"name": "x", "region": "us-east"}
+	assert.NoError(t, applySchemaDefaults(r, values))
+	assert.Equal(t, map[string]interface{}{"name": "x", "trusted": true, "region": "us-east"}, values)
+
+	values = map[string]interface{}{"trusted": false}
+	assert.NoError(t, applySchemaDefaults(r, values))
+	assert.Equal(t, map[string]interface{}{"trusted": false, "region": "eu-west"}, values)
+
+	block := &schema.Schema{Type: schema.TypeList, Elem: r}
+	v, err := coerceValue(block, map[string]interface{}{"name": "y"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "y", "trusted": true, "region": "eu-west"}}, v)
+}
+
+func TestBlueprintStateInsert(t *testing.T) {
+	entry := func(name string) *blueprintEntry { return &blueprintEntry{Kind: "device_group", Name: name} }
+	s := &blueprintState{entries: []*blueprintEntry{entry("a"), entry("c")}}
+	s.insert(1, entry("b"))
+	s.insert(5, entry("d"))
+	var names []string
+	for _, e := range s.entries {
+		names = append(names, e.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, names)
+	assert.Equal(t, 2, s.index("device_group.c"))
+	assert.Equal(t, -1, s.index("device_group.x"))
+}
@@ -0,0 +1,137 @@
+package mdm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+)
+
+func metaVersionID(meta *mdm.Meta) string {
+	if meta == nil {
+		return ""
+	}
+	return meta.VersionID
+}
+
+// lastReadMeta returns the meta to send with an update. It carries the version_id read last, so the server
+// rejects the update through If-Match when the resource changed since. With force_update set the current
+// version is sent, which overwrites remote changes
+func lastReadMeta(d *schema.ResourceData, current *mdm.Meta) *mdm.Meta {
+	lastRead := d.Get("version_id").(string)
+	if d.Get("force_update").(bool) || lastRead == "" {
+		return current
+	}
+	var meta mdm.Meta
+	if current != nil {
+		meta = *current
+	}
+	meta.VersionID = lastRead
+	return &meta
+}
+
+// checkVersionConflict compares the version_id read last with the current remote version. When they differ and
+// force_update is not set, it returns a diagnostic listing the remote changes. flatten writes the remote
+// resource to the resource data it is given
+func checkVersionConflict(d *schema.ResourceData, r *schema.Resource, remoteVersion string, flatten func(remote *schema.ResourceData)) diag.Diagnostics {
+	lastRead := d.Get("version_id").(string)
+	if d.Get("force_update").(bool) || lastRead == "" || remoteVersion == "" || lastRead == remoteVersion {
+		return nil
+	}
+	state := func(k string) interface{} {
+		old, _ := d.GetChange(k)
+		return old
+	}
+	// Attributes which only live in the state, e.g. triggers, keep their value so they are not reported
+	remote := r.Data(nil)
+	for k := range r.Schema {
+		_ = remote.Set(k, state(k))
+	}
+	flatten(remote)
+	return versionConflictDiags(d.Id(), lastRead, remoteVersion, remoteChanges(r.Schema, state, remote.Get))
+}
+
+// updateErrorDiags turns a rejected If-Match precondition into a conflict diagnostic
+func updateErrorDiags(d *schema.ResourceData, resp *mdm.Response, err error) diag.Diagnostics {
+	if resp != nil && (resp.StatusCode() == http.StatusConflict || resp.StatusCode() == http.StatusPreconditionFailed) {
+		return versionConflictDiags(d.Id(), d.Get("version_id").(string), "", nil)
+	}
+	return diag.FromErr(err)
+}
+
+func versionConflictDiags(id, lastRead, remoteVersion string, changes []string) diag.Diagnostics {
+	detail := fmt.Sprintf("The update was based on version %q", lastRead)
+	if remoteVersion != "" {
+		detail += fmt.Sprintf(", the current version is %q", remoteVersion)
+	}
+	detail += "."
+	if len(changes) > 0 {
+		detail += " Remote changes:\n  " + strings.Join(changes, "\n  ")
+	}
+	detail += "\n\nRefresh and review the plan to merge the remote changes, or set force_update = true to overwrite them."
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s was modified outside of this configuration", id),
+		Detail:   detail,
+	}}
+}
+
+// remoteChanges lists the configurable attributes whose remote value differs from the state
+func remoteChanges(s map[string]*schema.Schema, state, remote func(string) interface{}) []string {
+	var keys []string
+	for k, attr := range s {
+		if k == "principal" || k == "force_update" || attr.Sensitive || (attr.Computed && !attr.Optional) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, k := range keys {
+		old, current := normalizeChangeValue(state(k)), normalizeChangeValue(remote(k))
+		if reflect.DeepEqual(old, current) {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s => %s", k, describeChangeValue(old), describeChangeValue(current)))
+	}
+	return changes
+}
+
+func normalizeChangeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		items := v.List()
+		for i := range items {
+			items[i] = normalizeChangeValue(items[i])
+		}
+		return items
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i := range v {
+			items[i] = normalizeChangeValue(v[i])
+		}
+		return items
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = normalizeChangeValue(e)
+		}
+		return m
+	}
+	return value
+}
+
+func describeChangeValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,105 @@
+package mdm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/stretchr/testify/assert"
+)
+
+func versionedResource() *schema.Resource {
+	return &schema.Resource{Schema: map[string]*schema.Schema{
+		"description":  {Type: schema.TypeString, Optional: true},
+		"trigger":      {Type: schema.TypeString, Optional: true},
+		"version_id":   {Type: schema.TypeString, Computed: true},
+		"force_update": {Type: schema.TypeBool, Optional: true},
+	}}
+}
+
+func TestRemoteChanges(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"principal":    {Type: schema.TypeList, Optional: true},
+		"force_update": {Type: schema.TypeBool, Optional: true},
+		"name":         {Type: schema.TypeString, Required: true},
+		"description":  {Type: schema.TypeString, Optional: true},
+		"secret":       {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"version_id":   {Type: schema.TypeString, Computed: true},
+		"tags":         {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	}
+	state := map[string]interface{}{
+		"name":        "group",
+		"description": "old",
+		"secret":      "a",
+		"version_id":  "1",
+		"tags":        schema.NewSet(schema.HashString, []interface{}{"a"}),
+	}
+	remote := map[string]interface{}{
+		"name":        "group",
+		"description": "new",
+		"secret":      "b",
+		"version_id":  "2",
+		"tags":        schema.NewSet(schema.HashString, []interface{}{"a"}),
+	}
+	get := func(m map[string]interface{}) func(string) interface{} {
+		return func(k string) interface{} { return m[k] }
+	}
+
+	changes := remoteChanges(s, get(state), get(remote))
+	assert.Equal(t, []string{`description: "old" => "new"`}, changes)
+
+	remote["tags"] = schema.NewSet(schema.HashString, []interface{}{"c"})
+	changes = remoteChanges(s, get(state), get(remote))
+	assert.Equal(t, []string{`description: "old" => "new"`, `tags: ["a"] => ["c"]`}, changes)
+}
+
+func TestVersionConflictDiags(t *testing.T) {
+	diags := versionConflictDiags("DeviceGroup/1", "1", "2", []string{`description: "old" => "new"`})
+	if !assert.Len(t, diags, 1) {
+		return
+	}
+	assert.Equal(t, "DeviceGroup/1 was modified outside of this configuration", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, `based on version "1", the current version is "2"`)
+	assert.Contains(t, diags[0].Detail, `description: "old" => "new"`)
+	assert.Contains(t, diags[0].Detail, "force_update")
+
+	diags = versionConflictDiags("DeviceGroup/1", "1", "", nil)
+	assert.NotContains(t, diags[0].Detail, "current version")
+	assert.NotContains(t, diags[0].Detail, "Remote changes")
+}
+
+func TestLastReadMeta(t *testing.T) {
+	r := versionedResource()
+	d := r.Data(nil)
+	current := &mdm.Meta{VersionID: "3"}
+	assert.Equal(t, current, lastReadMeta(d, current))
+
+	_ = d.Set("version_id", "2")
+	meta := lastReadMeta(d, current)
+	assert.Equal(t, "2", meta.VersionID)
+	assert.Equal(t, "3", current.VersionID)
+	assert.Equal(t, "2", lastReadMeta(d, nil).VersionID)
+
+	_ = d.Set("force_update", true)
+	assert.Equal(t, current, lastReadMeta(d, current))
+}
+
+func TestCheckVersionConflict(t *testing.T) {
+	r := versionedResource()
+	d := r.Data(&terraform.InstanceState{ID: "1", Attributes: map[string]string{
+		"description": "old",
+		"trigger":     "only in state",
+		"version_id":  "1",
+	}})
+
+	flatten := func(remote *schema.ResourceData) {
+		_ = remote.Set("description", "new")
+	}
+	assert.Nil(t, checkVersionConflict(d, r, "1", flatten))
+	diags := checkVersionConflict(d, r, "2", flatten)
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Detail, `description: "old" => "new"`)
+		assert.NotContains(t, diags[0].Detail, "trigger")
+	}
+}
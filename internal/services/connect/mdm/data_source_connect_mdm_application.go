@@ -14,6 +14,7 @@ func DataSourceConnectMDMApplication() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMApplicationRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -60,7 +61,8 @@ func dataSourceConnectMDMApplicationRead(_ context.Context, d *schema.ResourceDa
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,77 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMAuthenticationMethod() *schema.Resource {
+	s := dataSourceSchema(ResourceConnectMDMAuthenticationMethod(), map[string]*schema.Schema{
+		"guid": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"guid", "name"},
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"organization_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	})
+	// Write-only secrets and the connectivity check are managed by the resource only
+	for _, k := range []string{"secrets_wo_version", "check_connectivity", "connectivity_status"} {
+		delete(s, k)
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMAuthenticationMethodRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceConnectMDMAuthenticationMethodRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resource *mdm.AuthenticationMethod
+	if guid := d.Get("guid").(string); guid != "" {
+		resource, _, err = client.AuthenticationMethods.GetByID(guid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		found, _, err := client.AuthenticationMethods.Find(&mdm.GetAuthenticationMethodOptions{
+			Name:           &name,
+			OrganizationID: optionalString(d.Get("organization_id").(string)),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found == nil || len(*found) == 0 {
+			return diag.FromErr(config.ErrResourceNotFound)
+		}
+		resource = &(*found)[0]
+	}
+
+	d.SetId(fmt.Sprintf("AuthenticationMethod/%s", resource.ID))
+	AuthenticationMethodToSchema(*resource, d)
+	return diags
+}
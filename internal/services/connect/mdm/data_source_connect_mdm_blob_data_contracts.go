@@ -0,0 +1,70 @@
+package mdm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMBlobDataContracts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMBlobDataContractsRead,
+		Schema:      listDataSourceSchema("names", "data_type_id", "proposition_id"),
+	}
+}
+
+func dataSourceConnectMDMBlobDataContractsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := listFilter(d)
+
+	dataTypeIDs, err := findDataTypeIDs(client, filter["data_type_id"], filter["proposition_id"])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var items []listItem
+	for _, id := range dataTypeIDs {
+		found, _, err := client.BlobDataContracts.Find(&mdm.GetBlobDataContractOptions{
+			Name:       optionalString(filter["name"]),
+			DataTypeID: optionalString(id),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, r := range *found {
+			items = append(items, listItem{Kind: "BlobDataContract", GUID: r.ID, Name: r.Name})
+		}
+	}
+	if err := setListItems(d, "names", items, filter); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// findDataTypeIDs returns the given data type, or the data types of the proposition when no data type is given
+func findDataTypeIDs(client *mdm.Client, dataTypeID, propID string) ([]string, error) {
+	if dataTypeID != "" || propID == "" {
+		return []string{dataTypeID}, nil
+	}
+	dataTypes, _, err := client.DataTypes.Find(&mdm.GetDataTypeOptions{
+		PropositionID: &propID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, dt := range *dataTypes {
+		ids = append(ids, "DataType/"+dt.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,52 @@
+package mdm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMBlobSubscriptions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMBlobSubscriptionsRead,
+		Schema:      listDataSourceSchema("names", "data_type_id", "proposition_id"),
+	}
+}
+
+func dataSourceConnectMDMBlobSubscriptionsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := listFilter(d)
+
+	dataTypeIDs, err := findDataTypeIDs(client, filter["data_type_id"], filter["proposition_id"])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var items []listItem
+	for _, id := range dataTypeIDs {
+		found, _, err := client.BlobSubscriptions.Find(&mdm.GetBlobSubscriptionOptions{
+			Name:       optionalString(filter["name"]),
+			DataTypeID: optionalString(id),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, r := range *found {
+			items = append(items, listItem{Kind: "BlobSubscription", GUID: r.ID, Name: r.Name})
+		}
+	}
+	if err := setListItems(d, "names", items, filter); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
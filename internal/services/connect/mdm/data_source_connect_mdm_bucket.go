@@ -15,6 +15,7 @@ func DataSourceConnectMDMBucket() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMBucketRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -73,7 +74,8 @@ func dataSourceConnectMDMBucketRead(_ context.Context, d *schema.ResourceData, m
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
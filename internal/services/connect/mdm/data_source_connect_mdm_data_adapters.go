@@ -13,6 +13,7 @@ func DataSourceConnectMDMDataAdapters() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMDataAdaptersRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,7 +44,8 @@ func dataSourceConnectMDMDataAdaptersRead(_ context.Context, d *schema.ResourceD
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -14,6 +14,7 @@ func DataSourceConnectMDMDataSubscribers() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMDataSubscribersRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -49,7 +50,8 @@ func dataSourceConnectMDMDataSubscribersRead(_ context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
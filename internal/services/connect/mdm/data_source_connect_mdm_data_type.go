@@ -15,6 +15,7 @@ func DataSourceConnectMDMDataType() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMDataTypeRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -46,7 +47,8 @@ func dataSourceConnectMDMDataTypeRead(_ context.Context, d *schema.ResourceData,
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,74 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMDeviceGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMDeviceGroupRead,
+		Schema: dataSourceSchema(ResourceConnectMDMDeviceGroup(), map[string]*schema.Schema{
+			"guid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"guid", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"application_id"},
+			},
+			"application_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func dataSourceConnectMDMDeviceGroupRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resource *mdm.DeviceGroup
+	if guid := d.Get("guid").(string); guid != "" {
+		resource, _, err = client.DeviceGroups.GetByID(guid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		appID := d.Get("application_id").(string)
+		found, _, err := client.DeviceGroups.Find(&mdm.GetDeviceGroupOptions{
+			Name:          &name,
+			ApplicationID: &appID,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found == nil || len(*found) == 0 {
+			return diag.FromErr(config.ErrResourceNotFound)
+		}
+		resource = &(*found)[0]
+	}
+
+	d.SetId(fmt.Sprintf("DeviceGroup/%s", resource.ID))
+	deviceGroupToSchema(*resource, d)
+	return diags
+}
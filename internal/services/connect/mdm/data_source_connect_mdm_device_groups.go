@@ -0,0 +1,71 @@
+package mdm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMDeviceGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMDeviceGroupsRead,
+		Schema:      listDataSourceSchema("names", "application_id", "proposition_id"),
+	}
+}
+
+func findDeviceGroups(client *mdm.Client, name, appID, propID string) ([]mdm.DeviceGroup, error) {
+	appIDs := []string{appID}
+	if appID == "" && propID != "" {
+		apps, _, err := client.Applications.GetApplications(&mdm.GetApplicationsOptions{
+			PropositionID: &propID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		appIDs = nil
+		for _, app := range *apps {
+			appIDs = append(appIDs, "Application/"+app.ID)
+		}
+	}
+	var groups []mdm.DeviceGroup
+	for _, id := range appIDs {
+		found, _, err := client.DeviceGroups.Find(&mdm.GetDeviceGroupOptions{
+			Name:          optionalString(name),
+			ApplicationID: optionalString(id),
+		})
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *found...)
+	}
+	return groups, nil
+}
+
+func dataSourceConnectMDMDeviceGroupsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := listFilter(d)
+
+	groups, err := findDeviceGroups(client, filter["name"], filter["application_id"], filter["proposition_id"])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var items []listItem
+	for _, g := range groups {
+		items = append(items, listItem{Kind: "DeviceGroup", GUID: g.ID, Name: g.Name})
+	}
+	if err := setListItems(d, "names", items, filter); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,79 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMDeviceType() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMDeviceTypeRead,
+		Schema: dataSourceSchema(ResourceConnectMDMDeviceType(), map[string]*schema.Schema{
+			"guid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"guid", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"device_group_id"},
+			},
+			"device_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func dataSourceConnectMDMDeviceTypeRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resource *mdm.DeviceType
+	if guid := d.Get("guid").(string); guid != "" {
+		resource, _, err = client.DeviceTypes.GetByID(guid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		groupID := d.Get("device_group_id").(string)
+		found, _, err := client.DeviceTypes.Find(&mdm.GetDeviceTypeOptions{
+			Name:          &name,
+			DeviceGroupID: &groupID,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found == nil || len(*found) == 0 {
+			return diag.FromErr(config.ErrResourceNotFound)
+		}
+		resource = &(*found)[0]
+	}
+
+	d.SetId(fmt.Sprintf("DeviceType/%s", resource.ID))
+	if err := DeviceTypeToSchema(*resource, d); err != nil {
+		return diag.FromErr(err)
+	}
+	if _, normalized, err := flattenCustomTypeAttributes(resource.CustomTypeAttributes); err == nil {
+		_ = d.Set("custom_type_attributes_json", normalized)
+	}
+	return diags
+}
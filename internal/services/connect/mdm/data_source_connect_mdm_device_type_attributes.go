@@ -0,0 +1,86 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMDeviceTypeAttributes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMDeviceTypeAttributesRead,
+		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
+			"device_type_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"types": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"attributes": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"attributes_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceConnectMDMDeviceTypeAttributesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	guid := strings.TrimPrefix(d.Get("device_type_id").(string), "DeviceType/")
+
+	resource, _, err := client.DeviceTypes.GetByID(guid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resource == nil {
+		return diag.FromErr(config.ErrResourceNotFound)
+	}
+	attributes, normalized, err := flattenCustomTypeAttributes(resource.CustomTypeAttributes)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("decoding custom type attributes of DeviceType/%s: %w", guid, err))
+	}
+	var keys []string
+	types := make(map[string]interface{})
+	if normalized != "" {
+		decoded, _ := parseCustomTypeAttributes(normalized)
+		for k, v := range decoded {
+			keys = append(keys, k)
+			types[k] = customTypeAttributeType(v)
+		}
+	}
+	sort.Strings(keys)
+
+	d.SetId(fmt.Sprintf("DeviceType/%s", resource.ID))
+	_ = d.Set("keys", keys)
+	_ = d.Set("types", types)
+	_ = d.Set("attributes", attributes)
+	_ = d.Set("attributes_json", normalized)
+	return diags
+}
@@ -0,0 +1,59 @@
+package mdm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMDeviceTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMDeviceTypesRead,
+		Schema:      listDataSourceSchema("names", "device_group_id", "application_id", "proposition_id"),
+	}
+}
+
+func dataSourceConnectMDMDeviceTypesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := listFilter(d)
+
+	groupIDs := []string{filter["device_group_id"]}
+	if filter["device_group_id"] == "" && (filter["application_id"] != "" || filter["proposition_id"] != "") {
+		groups, err := findDeviceGroups(client, "", filter["application_id"], filter["proposition_id"])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		groupIDs = nil
+		for _, g := range groups {
+			groupIDs = append(groupIDs, "DeviceGroup/"+g.ID)
+		}
+	}
+	var items []listItem
+	for _, id := range groupIDs {
+		found, _, err := client.DeviceTypes.Find(&mdm.GetDeviceTypeOptions{
+			Name:          optionalString(filter["name"]),
+			DeviceGroupID: optionalString(id),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, t := range *found {
+			items = append(items, listItem{Kind: "DeviceType", GUID: t.ID, Name: t.Name})
+		}
+	}
+	if err := setListItems(d, "names", items, filter); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,284 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMExportRead,
+		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
+			"proposition_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"import_blocks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"hcl": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"resource_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// mdmExporter walks a proposition and reads every object through its resource
+type mdmExporter struct {
+	ctx       context.Context
+	meta      interface{}
+	principal interface{}
+	objects   []exportObject
+	seen      map[string]bool
+	names     map[string]map[string]bool
+}
+
+// add reads the object with the given ID and records it. It returns nil when the object was already exported
+func (e *mdmExporter) add(resourceType string, r *schema.Resource, id string) (*schema.ResourceData, error) {
+	if id == "" || e.seen[id] {
+		return nil, nil
+	}
+	e.seen[id] = true
+
+	d := r.Data(nil)
+	d.SetId(id)
+	_ = d.Set("principal", e.principal)
+	if err := diagsError(r.ReadContext(e.ctx, d, e.meta)); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", id, err)
+	}
+	if d.Id() == "" {
+		return nil, nil
+	}
+	values := make(map[string]interface{}, len(r.Schema))
+	for k := range r.Schema {
+		values[k] = d.Get(k)
+	}
+	name, _ := values["name"].(string)
+	if name == "" {
+		name = id[strings.Index(id, "/")+1:]
+	}
+	if e.names[resourceType] == nil {
+		e.names[resourceType] = make(map[string]bool)
+	}
+	e.objects = append(e.objects, exportObject{
+		ResourceType: resourceType,
+		Name:         exportResourceName(name, e.names[resourceType]),
+		ID:           id,
+		Schema:       r.Schema,
+		Values:       values,
+	})
+	return d, nil
+}
+
+func (e *mdmExporter) exportProposition(client *mdm.Client, propID string) error {
+	if _, err := e.add("hsdp_connect_mdm_proposition", ResourceMDMProposition(), propID); err != nil {
+		return err
+	}
+
+	apps, _, err := client.Applications.GetApplications(&mdm.GetApplicationsOptions{
+		PropositionID: &propID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing applications: %w", err)
+	}
+	for _, app := range *apps {
+		if err := e.exportApplication(client, "Application/"+app.ID); err != nil {
+			return err
+		}
+	}
+
+	buckets, _, err := client.Buckets.Find(&mdm.GetBucketOptions{
+		PropositionID: &propID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing buckets: %w", err)
+	}
+	for _, bucket := range *buckets {
+		if _, err := e.add("hsdp_connect_mdm_bucket", ResourceConnectMDMBucket(), "Bucket/"+bucket.ID); err != nil {
+			return err
+		}
+	}
+
+	dataTypes, _, err := client.DataTypes.Find(&mdm.GetDataTypeOptions{
+		PropositionID: &propID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing data types: %w", err)
+	}
+	for _, dataType := range *dataTypes {
+		if err := e.exportDataType(client, "DataType/"+dataType.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *mdmExporter) exportApplication(client *mdm.Client, appID string) error {
+	if _, err := e.add("hsdp_connect_mdm_application", ResourceMDMApplication(), appID); err != nil {
+		return err
+	}
+
+	groups, _, err := client.DeviceGroups.Find(&mdm.GetDeviceGroupOptions{
+		ApplicationID: &appID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing device groups of %s: %w", appID, err)
+	}
+	for _, group := range *groups {
+		groupID := "DeviceGroup/" + group.ID
+		if _, err := e.add("hsdp_connect_mdm_device_group", ResourceConnectMDMDeviceGroup(), groupID); err != nil {
+			return err
+		}
+		deviceTypes, _, err := client.DeviceTypes.Find(&mdm.GetDeviceTypeOptions{
+			DeviceGroupID: &groupID,
+		})
+		if err != nil {
+			return fmt.Errorf("listing device types of %s: %w", groupID, err)
+		}
+		for _, deviceType := range *deviceTypes {
+			if _, err := e.add("hsdp_connect_mdm_device_type", ResourceConnectMDMDeviceType(), "DeviceType/"+deviceType.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	serviceReferences, _, err := client.ServiceReferences.Find(&mdm.GetServiceReferenceOptions{
+		ApplicationID: &appID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing service references of %s: %w", appID, err)
+	}
+	for _, ref := range *serviceReferences {
+		// Export the standard service first so the reference can point to it
+		if err := e.exportStandardService(client, ref.StandardServiceID.Reference); err != nil {
+			return err
+		}
+		if _, err := e.add("hsdp_connect_mdm_service_reference", ResourceConnectMDMServiceReference(), "ServiceReference/"+ref.ID); err != nil {
+			return err
+		}
+	}
+
+	clients, _, err := client.OAuthClients.GetOAuthClients(&mdm.GetOAuthClientsOptions{
+		ApplicationID: &appID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing OAuth clients of %s: %w", appID, err)
+	}
+	for _, oauthClient := range *clients {
+		if _, err := e.add("hsdp_connect_mdm_oauth_client", ResourceConnectMDMOAuthClient(), "OAuthClient/"+oauthClient.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *mdmExporter) exportStandardService(client *mdm.Client, serviceID string) error {
+	d, err := e.add("hsdp_connect_mdm_standard_service", ResourceConnectMDMStandardService(), serviceID)
+	if err != nil || d == nil {
+		return err
+	}
+	for _, raw := range d.Get("service_url").(*schema.Set).List() {
+		authMethodID, _ := raw.(map[string]interface{})["authentication_method_id"].(string)
+		if _, err := e.add("hsdp_connect_mdm_authentication_method", ResourceConnectMDMAuthenticationMethod(), authMethodID); err != nil {
+			return err
+		}
+	}
+
+	actions, _, err := client.ServiceActions.Find(&mdm.GetServiceActionOptions{
+		StandardServiceID: &serviceID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing service actions of %s: %w", serviceID, err)
+	}
+	for _, action := range *actions {
+		if _, err := e.add("hsdp_connect_mdm_service_action", ResourceConnectMDMServiceAction(), "ServiceAction/"+action.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *mdmExporter) exportDataType(client *mdm.Client, dataTypeID string) error {
+	if _, err := e.add("hsdp_connect_mdm_data_type", ResourceConnectMDMDataType(), dataTypeID); err != nil {
+		return err
+	}
+
+	contracts, _, err := client.BlobDataContracts.Find(&mdm.GetBlobDataContractOptions{
+		DataTypeID: &dataTypeID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing blob data contracts of %s: %w", dataTypeID, err)
+	}
+	for _, contract := range *contracts {
+		if _, err := e.add("hsdp_connect_mdm_blob_data_contract", ResourceConnectMDMBlobDataContract(), "BlobDataContract/"+contract.ID); err != nil {
+			return err
+		}
+	}
+
+	subscriptions, _, err := client.BlobSubscriptions.Find(&mdm.GetBlobSubscriptionOptions{
+		DataTypeID: &dataTypeID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing blob subscriptions of %s: %w", dataTypeID, err)
+	}
+	for _, subscription := range *subscriptions {
+		if _, err := e.add("hsdp_connect_mdm_blob_subscription", ResourceConnectMDMBlobSubscription(), "BlobSubscription/"+subscription.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dataSourceConnectMDMExportRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	propID := d.Get("proposition_id").(string)
+	if !strings.HasPrefix(propID, "Proposition/") {
+		propID = "Proposition/" + propID
+	}
+
+	exporter := &mdmExporter{
+		ctx:       ctx,
+		meta:      meta,
+		principal: d.Get("principal"),
+		seen:      make(map[string]bool),
+		names:     make(map[string]map[string]bool),
+	}
+	if err := exporter.exportProposition(client, propID); err != nil {
+		return diag.FromErr(err)
+	}
+	if len(exporter.objects) == 0 {
+		return diag.FromErr(config.ErrResourceNotFound)
+	}
+
+	ids := make(map[string]string)
+	for _, o := range exporter.objects {
+		ids[o.address()] = o.ID
+	}
+	d.SetId(propID)
+	_ = d.Set("hcl", renderExport(exporter.objects, d.Get("import_blocks").(bool)))
+	_ = d.Set("resource_ids", ids)
+	return diags
+}
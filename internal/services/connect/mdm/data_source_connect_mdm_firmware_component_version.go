@@ -0,0 +1,74 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMFirmwareComponentVersion() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMFirmwareComponentVersionRead,
+		Schema: dataSourceSchema(ResourceConnectMDMFirmwareComponentVersion(), map[string]*schema.Schema{
+			"guid": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"guid", "version"},
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"firmware_component_id"},
+			},
+			"firmware_component_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func dataSourceConnectMDMFirmwareComponentVersionRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resource *mdm.FirmwareComponentVersion
+	if guid := d.Get("guid").(string); guid != "" {
+		resource, _, err = client.FirmwareComponentVersions.GetByID(guid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		version := d.Get("version").(string)
+		componentID := d.Get("firmware_component_id").(string)
+		found, _, err := client.FirmwareComponentVersions.Find(&mdm.GetFirmwareComponentVersionOptions{
+			Version:             &version,
+			FirmwareComponentID: &componentID,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found == nil || len(*found) == 0 {
+			return diag.FromErr(config.ErrResourceNotFound)
+		}
+		resource = &(*found)[0]
+	}
+
+	d.SetId(fmt.Sprintf("FirmwareComponentVersion/%s", resource.ID))
+	firmwareComponentVersionToSchema(*resource, d)
+	return diags
+}
@@ -0,0 +1,46 @@
+package mdm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMFirmwareComponentVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMFirmwareComponentVersionsRead,
+		Schema:      listDataSourceSchema("versions", "firmware_component_id"),
+	}
+}
+
+func dataSourceConnectMDMFirmwareComponentVersionsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := listFilter(d)
+
+	found, _, err := client.FirmwareComponentVersions.Find(&mdm.GetFirmwareComponentVersionOptions{
+		Version:             optionalString(filter["name"]),
+		FirmwareComponentID: optionalString(filter["firmware_component_id"]),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var items []listItem
+	for _, v := range *found {
+		items = append(items, listItem{Kind: "FirmwareComponentVersion", GUID: v.ID, Name: v.Version})
+	}
+	if err := setListItems(d, "versions", items, filter); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,46 @@
+package mdm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMFirmwareComponents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMFirmwareComponentsRead,
+		Schema:      listDataSourceSchema("names", "device_type_id"),
+	}
+}
+
+func dataSourceConnectMDMFirmwareComponentsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := listFilter(d)
+
+	found, _, err := client.FirmwareComponents.Find(&mdm.GetFirmwareComponentOptions{
+		Name:         optionalString(filter["name"]),
+		DeviceTypeID: optionalString(filter["device_type_id"]),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var items []listItem
+	for _, fc := range *found {
+		items = append(items, listItem{Kind: "FirmwareComponent", GUID: fc.ID, Name: fc.Name})
+	}
+	if err := setListItems(d, "names", items, filter); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
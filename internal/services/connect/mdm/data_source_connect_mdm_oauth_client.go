@@ -0,0 +1,85 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMOAuthClient() *schema.Resource {
+	s := dataSourceSchema(ResourceConnectMDMOAuthClient(), map[string]*schema.Schema{
+		"guid": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"guid", "name"},
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			RequiredWith: []string{"application_id"},
+		},
+		"application_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	})
+	// The resource splits scopes between MDM and IAM based on its configuration, which a data source does not have
+	for _, k := range []string{"scopes", "default_scopes", "iam_scopes", "iam_default_scopes",
+		"bootstrap_client_scopes", "bootstrap_client_default_scopes",
+		"bootstrap_client_iam_scopes", "bootstrap_client_iam_default_scopes"} {
+		delete(s, k)
+	}
+	// Secret rotation is tracked in the state of the resource only
+	for _, k := range []string{"rotation_trigger", "secret_version", "rotated_at"} {
+		delete(s, k)
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMOAuthClientRead,
+		Schema:      s,
+	}
+}
+
+func dataSourceConnectMDMOAuthClientRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var resource *mdm.OAuthClient
+	if guid := d.Get("guid").(string); guid != "" {
+		resource, _, err = client.OAuthClients.GetOAuthClientByID(guid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		name := d.Get("name").(string)
+		appID := d.Get("application_id").(string)
+		found, _, err := client.OAuthClients.GetOAuthClients(&mdm.GetOAuthClientsOptions{
+			Name:          &name,
+			ApplicationID: &appID,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found == nil || len(*found) == 0 {
+			return diag.FromErr(config.ErrResourceNotFound)
+		}
+		resource = &(*found)[0]
+	}
+
+	d.SetId(fmt.Sprintf("OAuthClient/%s", resource.ID))
+	oAuthClientToSchema(*resource, d)
+	return diags
+}
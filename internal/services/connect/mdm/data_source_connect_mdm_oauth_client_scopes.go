@@ -14,6 +14,7 @@ func DataSourceConnectMDMOauthClientScopes() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMOAuthClientScopesRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -69,7 +70,8 @@ func dataSourceConnectMDMOAuthClientScopesRead(_ context.Context, d *schema.Reso
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
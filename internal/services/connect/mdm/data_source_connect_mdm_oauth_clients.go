@@ -0,0 +1,62 @@
+package mdm
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceConnectMDMOAuthClients() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectMDMOAuthClientsRead,
+		Schema:      listDataSourceSchema("names", "application_id", "proposition_id"),
+	}
+}
+
+func dataSourceConnectMDMOAuthClientsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	filter := listFilter(d)
+
+	appIDs := []string{filter["application_id"]}
+	if filter["application_id"] == "" && filter["proposition_id"] != "" {
+		propID := filter["proposition_id"]
+		apps, _, err := client.Applications.GetApplications(&mdm.GetApplicationsOptions{
+			PropositionID: &propID,
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		appIDs = nil
+		for _, app := range *apps {
+			appIDs = append(appIDs, "Application/"+app.ID)
+		}
+	}
+	var items []listItem
+	for _, id := range appIDs {
+		found, _, err := client.OAuthClients.GetOAuthClients(&mdm.GetOAuthClientsOptions{
+			Name:          optionalString(filter["name"]),
+			ApplicationID: optionalString(id),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, oc := range *found {
+			items = append(items, listItem{Kind: "OAuthClient", GUID: oc.ID, Name: oc.Name})
+		}
+	}
+	if err := setListItems(d, "names", items, filter); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -16,6 +16,7 @@ func DataSourceConnectMDMProposition() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMPropositionRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -67,7 +68,8 @@ func dataSourceConnectMDMPropositionRead(_ context.Context, d *schema.ResourceDa
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
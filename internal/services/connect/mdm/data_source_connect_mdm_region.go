@@ -14,6 +14,7 @@ func DataSourceConnectMDMRegion() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMRegionRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -44,7 +45,8 @@ func dataSourceConnectMDMRegionRead(_ context.Context, d *schema.ResourceData, m
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
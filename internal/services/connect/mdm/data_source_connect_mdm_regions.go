@@ -16,6 +16,7 @@ func DataSourceConnectMDMRegions() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMRegionsRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -51,7 +52,8 @@ func dataSourceConnectMDMRegionsRead(ctx context.Context, d *schema.ResourceData
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -12,6 +12,7 @@ func DataSourceResourceLimits() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMResourcesLimitsRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"resources": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -37,7 +38,8 @@ func dataSourceConnectMDMResourcesLimitsRead(_ context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
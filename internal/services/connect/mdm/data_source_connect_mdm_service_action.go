@@ -14,6 +14,7 @@ func DataSourceConnectMDMServiceAction() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMServiceActionRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -48,7 +49,8 @@ func dataSourceConnectMDMServiceActionRead(_ context.Context, d *schema.Resource
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
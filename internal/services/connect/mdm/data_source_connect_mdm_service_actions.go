@@ -14,6 +14,7 @@ func DataSourceConnectMDMServiceActions() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMServiceActionsRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -75,7 +76,8 @@ func dataSourceConnectMDMServiceActionsRead(_ context.Context, d *schema.Resourc
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -15,6 +15,7 @@ func DataSourceConnectMDMServiceAgent() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMServiceAgentRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -54,7 +55,8 @@ func dataSourceConnectMDMServiceAgentRead(_ context.Context, d *schema.ResourceD
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
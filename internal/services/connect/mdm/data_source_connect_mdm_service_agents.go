@@ -13,6 +13,7 @@ func DataSourceConnectMDMServiceAgents() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMServiceAgentsRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -53,7 +54,8 @@ func dataSourceConnectMDMServiceAgentsRead(_ context.Context, d *schema.Resource
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
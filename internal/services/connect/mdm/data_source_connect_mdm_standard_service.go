@@ -15,6 +15,7 @@ func DataSourceConnectMDMStandardService() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMStandardServiceRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -55,7 +56,8 @@ func dataSourceConnectMDMStandardServiceRead(_ context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
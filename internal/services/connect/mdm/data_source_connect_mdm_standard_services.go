@@ -13,6 +13,7 @@ func DataSourceConnectMDMStandardServices() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMStandardServicesRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -43,7 +44,8 @@ func dataSourceConnectMDMStandardServicesRead(_ context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -14,6 +14,7 @@ func DataSourceConnectMDMStorageClass() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMStorageClassRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -36,7 +37,8 @@ func dataSourceConnectMDMStorageClassRead(_ context.Context, d *schema.ResourceD
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("get MDMClient error: %w", err))
 	}
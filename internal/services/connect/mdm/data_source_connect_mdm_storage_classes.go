@@ -13,6 +13,7 @@ func DataSourceConnectMDMStorageClasses() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMStorageClassesRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -38,7 +39,8 @@ func dataSourceConnectMDMStorageClassesRead(_ context.Context, d *schema.Resourc
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -13,6 +13,7 @@ func DataSourceConnectMDMSubscriberTypes() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConnectMDMSubscriberTypesRead,
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -48,7 +49,8 @@ func dataSourceConnectMDMSubscriberTypesRead(_ context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, meta)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,173 @@
+package mdm
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+// dataSourceSchema returns the attributes of a resource as computed attributes, together with the
+// lookup arguments of the data source. Sensitive and update-only attributes are left out
+func dataSourceSchema(r *schema.Resource, arguments map[string]*schema.Schema) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema)
+	for k, s := range r.Schema {
+		if s.Sensitive || k == "principal" || k == "force_update" || k == "source" || k == "upload" {
+			continue
+		}
+		result[k] = computedAttribute(s)
+	}
+	for k, s := range arguments {
+		result[k] = s
+	}
+	result["principal"] = config.PrincipalSchema()
+	return result
+}
+
+func computedAttribute(s *schema.Schema) *schema.Schema {
+	c := &schema.Schema{
+		Type:     s.Type,
+		Computed: true,
+	}
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		nested := make(map[string]*schema.Schema)
+		for k, e := range elem.Schema {
+			if e.Sensitive {
+				continue
+			}
+			nested[k] = computedAttribute(e)
+		}
+		c.Elem = &schema.Resource{Schema: nested}
+	case *schema.Schema:
+		c.Elem = &schema.Schema{Type: elem.Type}
+	}
+	return c
+}
+
+// listDataSourceSchema returns the schema of a data source listing MDM objects. The filter block supports
+// name, name_regex and the given filters. nameKey is the attribute holding the names of the objects found
+func listDataSourceSchema(nameKey string, filters ...string) map[string]*schema.Schema {
+	filter := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateRegex,
+		},
+	}
+	for _, f := range filters {
+		filter[f] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+	return map[string]*schema.Schema{
+		"principal": config.PrincipalSchema(),
+		"filter": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			MaxItems: 1,
+			ForceNew: true,
+			Elem:     &schema.Resource{Schema: filter},
+		},
+		"ids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"guids": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		nameKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func validateRegex(v interface{}, k string) (warnings []string, errs []error) {
+	if _, err := regexp.Compile(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q: %w", k, err))
+	}
+	return
+}
+
+// listFilter returns the values of the filter block, keyed by filter name
+func listFilter(d *schema.ResourceData) map[string]string {
+	result := make(map[string]string)
+	if v, ok := d.GetOk("filter"); ok {
+		for _, vi := range v.(*schema.Set).List() {
+			for k, value := range vi.(map[string]interface{}) {
+				result[k] = value.(string)
+			}
+		}
+	}
+	return result
+}
+
+// optionalString returns nil for an empty value, so it is left out of search parameters
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// listItem is an MDM object found by a list data source
+type listItem struct {
+	Kind string
+	GUID string
+	Name string
+}
+
+// filterListItems keeps the items matching the name filter and the name pattern, in order and without duplicates
+func filterListItems(items []listItem, name, pattern string) ([]listItem, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var result []listItem
+	for _, item := range items {
+		if seen[item.GUID] || (name != "" && item.Name != name) || !re.MatchString(item.Name) {
+			continue
+		}
+		seen[item.GUID] = true
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+func setListItems(d *schema.ResourceData, nameKey string, items []listItem, filter map[string]string) error {
+	items, err := filterListItems(items, filter["name"], filter["name_regex"])
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(items))
+	guids := make([]string, 0, len(items))
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, fmt.Sprintf("%s/%s", item.Kind, item.GUID))
+		guids = append(guids, item.GUID)
+		names = append(names, item.Name)
+	}
+	_ = d.Set("ids", ids)
+	_ = d.Set("guids", guids)
+	_ = d.Set(nameKey, names)
+
+	result, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	d.SetId(result)
+	return nil
+}
@@ -0,0 +1,73 @@
+package mdm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterListItems(t *testing.T) {
+	items := []listItem{
+		{Kind: "DeviceGroup", GUID: "1", Name: "fleet-eu"},
+		{Kind: "DeviceGroup", GUID: "2", Name: "fleet-us"},
+		{Kind: "DeviceGroup", GUID: "3", Name: "lab"},
+		{Kind: "DeviceGroup", GUID: "1", Name: "fleet-eu"},
+	}
+
+	found, err := filterListItems(items, "", "")
+	assert.NoError(t, err)
+	assert.Len(t, found, 3)
+
+	found, err = filterListItems(items, "", "^fleet-")
+	assert.NoError(t, err)
+	assert.Equal(t, []listItem{items[0], items[1]}, found)
+
+	found, err = filterListItems(items, "lab", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []listItem{items[2]}, found)
+
+	_, err = filterListItems(items, "", "(")
+	assert.Error(t, err)
+}
+
+func TestDataSourceSchema(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"principal":    {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{}},
+			"force_update": {Type: schema.TypeBool, Optional: true},
+			"name":         {Type: schema.TypeString, Required: true, ForceNew: true},
+			"password":     {Type: schema.TypeString, Optional: true, Sensitive: true},
+			"tags":         {Type: schema.TypeSet, Optional: true, MaxItems: 5, Elem: &schema.Schema{Type: schema.TypeString}},
+			"fingerprint": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"hash": {Type: schema.TypeString, Required: true},
+					"key":  {Type: schema.TypeString, Optional: true, Sensitive: true},
+				}},
+			},
+			"guid": {Type: schema.TypeString, Computed: true},
+		},
+	}
+	s := dataSourceSchema(r, map[string]*schema.Schema{
+		"guid": {Type: schema.TypeString, Optional: true, Computed: true},
+	})
+
+	assert.NotContains(t, s, "force_update")
+	assert.NotContains(t, s, "password")
+	assert.Contains(t, s, "principal")
+	assert.True(t, s["name"].Computed)
+	assert.False(t, s["name"].Required)
+	assert.False(t, s["name"].ForceNew)
+	assert.Equal(t, 0, s["tags"].MaxItems)
+	assert.True(t, s["guid"].Optional)
+	nested := s["fingerprint"].Elem.(*schema.Resource).Schema
+	assert.Contains(t, nested, "hash")
+	assert.NotContains(t, nested, "key")
+
+	ds := &schema.Resource{Schema: s}
+	assert.NoError(t, ds.InternalValidate(nil, false))
+	assert.NoError(t, (&schema.Resource{Schema: listDataSourceSchema("names", "application_id")}).InternalValidate(nil, false))
+}
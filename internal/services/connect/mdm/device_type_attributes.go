@@ -0,0 +1,251 @@
+package mdm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// validateCustomTypeAttributes checks the keys of the custom_type_attributes map
+func validateCustomTypeAttributes(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attributes, ok := v.(map[string]interface{})
+	if !ok {
+		return diag.FromErr(fmt.Errorf("expected %q to be a map", v))
+	}
+	for k := range attributes {
+		if err := validateCustomTypeAttributeKey(k); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid custom type attribute",
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+	}
+	return diags
+}
+
+// validateCustomTypeAttributesJSON checks custom_type_attributes_json holds a JSON object without null values
+func validateCustomTypeAttributesJSON(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	value, ok := v.(string)
+	if !ok {
+		return diag.FromErr(fmt.Errorf("expected %q to be a string", v))
+	}
+	attributes, err := parseCustomTypeAttributes(value)
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "invalid custom type attributes",
+			Detail:        err.Error(),
+			AttributePath: path,
+		})
+	}
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		err := validateCustomTypeAttributeKey(k)
+		if err == nil && attributes[k] == nil {
+			// The API drops null values, which would show up as a diff on every plan
+			err = fmt.Errorf("attribute %q has a null value, remove it instead", k)
+		}
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "invalid custom type attribute",
+				Detail:        err.Error(),
+				AttributePath: path,
+			})
+		}
+	}
+	return diags
+}
+
+func validateCustomTypeAttributeKey(k string) error {
+	if strings.TrimSpace(k) == "" {
+		return fmt.Errorf("attribute names can not be empty")
+	}
+	if strings.TrimSpace(k) != k {
+		return fmt.Errorf("attribute %q has leading or trailing whitespace", k)
+	}
+	return nil
+}
+
+// parseCustomTypeAttributes decodes custom type attributes, which must be a JSON object
+func parseCustomTypeAttributes(value string) (map[string]interface{}, error) {
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("malformed JSON: %w", err)
+	}
+	attributes, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object, got %s", customTypeAttributeType(decoded))
+	}
+	return attributes, nil
+}
+
+// normalizeCustomTypeAttributes returns the compact JSON encoding of the attributes with sorted keys
+func normalizeCustomTypeAttributes(value string) (string, error) {
+	attributes, err := parseCustomTypeAttributes(value)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// suppressEquivalentCustomTypeAttributes ignores differences in key order and formatting
+func suppressEquivalentCustomTypeAttributes(_, old, new string, _ *schema.ResourceData) bool {
+	oldAttributes, err := parseCustomTypeAttributes(old)
+	if err != nil {
+		return false
+	}
+	newAttributes, err := parseCustomTypeAttributes(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldAttributes, newAttributes)
+}
+
+// customTypeAttributeType returns the JSON type of a decoded value
+func customTypeAttributeType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// customTypeAttributeString returns the value as a string for the custom_type_attributes map. Values which
+// are not strings are JSON encoded
+func customTypeAttributeString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// flattenCustomTypeAttributes returns the attributes as a map of strings and as normalized JSON
+func flattenCustomTypeAttributes(raw json.RawMessage) (map[string]interface{}, string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, "", nil
+	}
+	normalized, err := normalizeCustomTypeAttributes(string(raw))
+	if err != nil {
+		return nil, "", err
+	}
+	attributes, _ := parseCustomTypeAttributes(normalized)
+	result := make(map[string]interface{}, len(attributes))
+	for k, v := range attributes {
+		result[k] = customTypeAttributeString(v)
+	}
+	return result, normalized, nil
+}
+
+// customTypeAttributesToSchema sets the attributes in the form used by the configuration. When
+// custom_type_attributes_json is in use the map is left empty, and vice versa
+func customTypeAttributesToSchema(raw json.RawMessage, d *schema.ResourceData) error {
+	attributes, normalized, err := flattenCustomTypeAttributes(raw)
+	if err != nil {
+		return fmt.Errorf("decoding custom type attributes: %w", err)
+	}
+	if d.Get("custom_type_attributes_json").(string) != "" {
+		_ = d.Set("custom_type_attributes_json", normalized)
+		_ = d.Set("custom_type_attributes", nil)
+		return nil
+	}
+	_ = d.Set("custom_type_attributes", attributes)
+	return nil
+}
+
+// customizeDeviceTypeDiff rejects changes to the type of existing custom type attributes during plan, as
+// devices of the type rely on it. New attributes can have any type. Only attributes previously set through
+// custom_type_attributes_json are checked
+func customizeDeviceTypeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChanges("custom_type_attributes", "custom_type_attributes_json") {
+		return nil
+	}
+	if !d.NewValueKnown("custom_type_attributes") || !d.NewValueKnown("custom_type_attributes_json") {
+		return nil
+	}
+	oldMap, newMap := d.GetChange("custom_type_attributes")
+	oldJSON, newJSON := d.GetChange("custom_type_attributes_json")
+	if oldJSON.(string) == "" {
+		// The map holds every attribute as a string, so it does not tell the types stored in MDM
+		return nil
+	}
+	previous, err := typedCustomTypeAttributes(oldMap, oldJSON)
+	if err != nil {
+		// Attributes which can not be decoded are reported when reading the resource
+		return nil
+	}
+	next, err := typedCustomTypeAttributes(newMap, newJSON)
+	if err != nil {
+		return err
+	}
+	return checkCustomTypeAttributeTypes(previous, next)
+}
+
+// typedCustomTypeAttributes returns the attributes from whichever of custom_type_attributes_json or
+// custom_type_attributes is set, keeping the JSON types
+func typedCustomTypeAttributes(attributesMap, attributesJSON interface{}) (map[string]interface{}, error) {
+	if value, _ := attributesJSON.(string); value != "" {
+		return parseCustomTypeAttributes(value)
+	}
+	attributes, _ := attributesMap.(map[string]interface{})
+	return attributes, nil
+}
+
+// checkCustomTypeAttributeTypes returns an error for each attribute of next whose JSON type differs from the
+// same attribute in previous
+func checkCustomTypeAttributeTypes(previous, next map[string]interface{}) error {
+	keys := make([]string, 0, len(next))
+	for k := range next {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var mismatches []string
+	for _, k := range keys {
+		old, ok := previous[k]
+		if !ok || old == nil || next[k] == nil {
+			continue
+		}
+		if expected, got := customTypeAttributeType(old), customTypeAttributeType(next[k]); expected != got {
+			mismatches = append(mismatches, fmt.Sprintf("attribute %q has type %s, got %s", k, expected, got))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("custom type attributes can not change type: %s", strings.Join(mismatches, ", "))
+	}
+	return nil
+}
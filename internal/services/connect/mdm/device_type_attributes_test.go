@@ -0,0 +1,85 @@
+package mdm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCustomTypeAttributesJSON(t *testing.T) {
+	path := cty.GetAttrPath("custom_type_attributes_json")
+
+	assert.False(t, validateCustomTypeAttributesJSON(`{"position":"wrist","channels":2}`, path).HasError())
+	assert.True(t, validateCustomTypeAttributesJSON(`{"position":`, path).HasError())
+	assert.True(t, validateCustomTypeAttributesJSON(`["position"]`, path).HasError())
+	assert.True(t, validateCustomTypeAttributesJSON(`{"position":null}`, path).HasError())
+	assert.True(t, validateCustomTypeAttributesJSON(`{" position":"wrist"}`, path).HasError())
+
+	diags := validateCustomTypeAttributes(map[string]interface{}{"": "x", "region": "eu"}, cty.GetAttrPath("custom_type_attributes"))
+	assert.Len(t, diags, 1)
+}
+
+func TestNormalizeCustomTypeAttributes(t *testing.T) {
+	normalized, err := normalizeCustomTypeAttributes("{\n  \"region\": \"eu\",\n  \"channels\": 2.0,\n  \"position\": \"wrist\"\n}")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"channels":2,"position":"wrist","region":"eu"}`, normalized)
+
+	assert.True(t, suppressEquivalentCustomTypeAttributes("", `{"a":"1","b":true}`, `{ "b": true, "a": "1" }`, nil))
+	assert.False(t, suppressEquivalentCustomTypeAttributes("", `{"a":"1"}`, `{"a":1}`, nil))
+	assert.False(t, suppressEquivalentCustomTypeAttributes("", `{"a":"1"}`, `{"a":`, nil))
+}
+
+func TestFlattenCustomTypeAttributes(t *testing.T) {
+	attributes, normalized, err := flattenCustomTypeAttributes(json.RawMessage(`{"region":"eu","channels":2,"sensors":["hr"],"wearable":true}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"channels":2,"region":"eu","sensors":["hr"],"wearable":true}`, normalized)
+	assert.Equal(t, map[string]interface{}{
+		"region":   "eu",
+		"channels": "2",
+		"sensors":  `["hr"]`,
+		"wearable": "true",
+	}, attributes)
+
+	attributes, normalized, err = flattenCustomTypeAttributes(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, attributes)
+	assert.Equal(t, "", normalized)
+
+	assert.Equal(t, "number", customTypeAttributeType(2.0))
+	assert.Equal(t, "array", customTypeAttributeType([]interface{}{}))
+}
+
+func TestCheckCustomTypeAttributeTypes(t *testing.T) {
+	previous, err := typedCustomTypeAttributes(nil, `{"channels":2,"position":"wrist","sensors":["hr"]}`)
+	assert.NoError(t, err)
+
+	next, err := typedCustomTypeAttributes(nil, `{"channels":4,"position":"ankle","wearable":true}`)
+	assert.NoError(t, err)
+	assert.NoError(t, checkCustomTypeAttributeTypes(previous, next))
+
+	next, err = typedCustomTypeAttributes(nil, `{"channels":"4","sensors":{"hr":true}}`)
+	assert.NoError(t, err)
+	err = checkCustomTypeAttributeTypes(previous, next)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `attribute "channels" has type number, got string`)
+		assert.Contains(t, err.Error(), `attribute "sensors" has type array, got object`)
+	}
+
+	next, _ = typedCustomTypeAttributes(map[string]interface{}{"channels": "2"}, "")
+	assert.Error(t, checkCustomTypeAttributeTypes(previous, next))
+
+	_, err = typedCustomTypeAttributes(nil, `{"channels":`)
+	assert.Error(t, err)
+}
+
+func TestCustomTypeAttributesToSchema(t *testing.T) {
+	r := ResourceConnectMDMDeviceType()
+	d := r.TestResourceData()
+
+	assert.NoError(t, customTypeAttributesToSchema(json.RawMessage(`{"region":"eu"}`), d))
+	assert.Equal(t, map[string]interface{}{"region": "eu"}, d.Get("custom_type_attributes"))
+
+	assert.Error(t, customTypeAttributesToSchema(json.RawMessage(`["region"]`), d))
+}
@@ -0,0 +1,234 @@
+package mdm
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// exportObject is an MDM object read through its resource, ready to be rendered as HCL
+type exportObject struct {
+	ResourceType string
+	Name         string
+	ID           string
+	Schema       map[string]*schema.Schema
+	Values       map[string]interface{}
+}
+
+func (o exportObject) address() string {
+	return o.ResourceType + "." + o.Name
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// exportResourceName turns an MDM object name into a unique Terraform resource name
+func exportResourceName(name string, used map[string]bool) string {
+	base := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || !(base[0] >= 'a' && base[0] <= 'z' || base[0] == '_') {
+		base = "r_" + base
+	}
+	result := base
+	for i := 2; used[result]; i++ {
+		result = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[result] = true
+	return result
+}
+
+// exportWriter renders resource blocks and collects the variables that stand in for sensitive values
+type exportWriter struct {
+	refs          map[string]hcl.Traversal
+	variables     []string
+	usedVariables map[string]bool
+}
+
+// variable declares a variable for a required sensitive attribute, which can not be exported, and returns
+// a reference to it
+func (w *exportWriter) variable(prefix, key string) hcl.Traversal {
+	name := exportResourceName(prefix+"_"+key, w.usedVariables)
+	w.variables = append(w.variables, name)
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: name},
+	}
+}
+
+// renderExport renders the objects as resource blocks. References to the IDs of other exported objects
+// become resource references. With imports set, every resource is preceded by an import block. Required
+// sensitive attributes refer to sensitive variables, which are declared at the top
+func renderExport(objects []exportObject, imports bool) string {
+	w := &exportWriter{
+		refs:          make(map[string]hcl.Traversal),
+		usedVariables: make(map[string]bool),
+	}
+	for _, o := range objects {
+		w.refs[o.ID] = hcl.Traversal{
+			hcl.TraverseRoot{Name: o.ResourceType},
+			hcl.TraverseAttr{Name: o.Name},
+			hcl.TraverseAttr{Name: "id"},
+		}
+	}
+
+	resources := hclwrite.NewEmptyFile()
+	body := resources.Body()
+	for i, o := range objects {
+		if i > 0 {
+			body.AppendNewline()
+		}
+		if imports {
+			block := body.AppendNewBlock("import", nil)
+			block.Body().SetAttributeTraversal("to", hcl.Traversal{
+				hcl.TraverseRoot{Name: o.ResourceType},
+				hcl.TraverseAttr{Name: o.Name},
+			})
+			block.Body().SetAttributeValue("id", cty.StringVal(o.ID))
+			body.AppendNewline()
+		}
+		block := body.AppendNewBlock("resource", []string{o.ResourceType, o.Name})
+		prefix := strings.TrimPrefix(o.ResourceType, "hsdp_connect_mdm_") + "_" + o.Name
+		w.writeAttributes(block.Body(), o.Schema, o.Values, prefix)
+	}
+	if len(w.variables) == 0 {
+		return string(hclwrite.Format(resources.Bytes()))
+	}
+
+	f := hclwrite.NewEmptyFile()
+	for _, name := range w.variables {
+		block := f.Body().AppendNewBlock("variable", []string{name})
+		block.Body().SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		block.Body().SetAttributeValue("sensitive", cty.True)
+		f.Body().AppendNewline()
+	}
+	return string(hclwrite.Format(append(f.Bytes(), resources.Bytes()...)))
+}
+
+// writeAttributes writes the configurable attributes that differ from their defaults, followed by nested blocks
+func (w *exportWriter) writeAttributes(body *hclwrite.Body, s map[string]*schema.Schema, values map[string]interface{}, prefix string) {
+	var keys []string
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var blocks []string
+	for _, k := range keys {
+		attr := s[k]
+		v := values[k]
+		if k != "principal" && attr.Sensitive && attr.Required {
+			body.SetAttributeTraversal(k, w.variable(prefix, k))
+			continue
+		}
+		if !exportable(k, attr, v) {
+			continue
+		}
+		if _, ok := attr.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+			continue
+		}
+		body.SetAttributeRaw(k, exportTokens(attr, v, w.refs))
+	}
+	for _, k := range blocks {
+		elem := s[k].Elem.(*schema.Resource)
+		for i, item := range exportList(values[k]) {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			block := body.AppendNewBlock(k, nil)
+			w.writeAttributes(block.Body(), elem.Schema, m, fmt.Sprintf("%s_%s_%d", prefix, k, i))
+		}
+	}
+}
+
+func exportable(key string, s *schema.Schema, value interface{}) bool {
+	if key == "principal" || s.Sensitive || s.Deprecated != "" || (s.Computed && !s.Optional) {
+		return false
+	}
+	if s.Required {
+		return true
+	}
+	if s.Default != nil {
+		return !reflect.DeepEqual(value, s.Default)
+	}
+	return !isZeroExportValue(value)
+}
+
+func isZeroExportValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	return false
+}
+
+func exportList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	}
+	return nil
+}
+
+func exportTokens(s *schema.Schema, value interface{}, refs map[string]hcl.Traversal) hclwrite.Tokens {
+	var elem *schema.Schema
+	if s != nil {
+		elem, _ = s.Elem.(*schema.Schema)
+	}
+	switch v := value.(type) {
+	case string:
+		if ref, ok := refs[v]; ok {
+			return hclwrite.TokensForTraversal(ref)
+		}
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case []interface{}, *schema.Set:
+		var elems []hclwrite.Tokens
+		for _, e := range exportList(v) {
+			elems = append(elems, exportTokens(elem, e, refs))
+		}
+		return hclwrite.TokensForTuple(elems)
+	case map[string]interface{}:
+		var keys []string
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var attrs []hclwrite.ObjectAttrTokens
+		for _, k := range keys {
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(k)),
+				Value: exportTokens(elem, v[k], refs),
+			})
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType))
+}
@@ -0,0 +1,125 @@
+package mdm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportResourceName(t *testing.T) {
+	used := make(map[string]bool)
+	assert.Equal(t, "my_app", exportResourceName("My App", used))
+	assert.Equal(t, "my_app_2", exportResourceName("my.app", used))
+	assert.Equal(t, "r_1st", exportResourceName("1st", used))
+	assert.Equal(t, "r_", exportResourceName("***", used))
+}
+
+func TestRenderExport(t *testing.T) {
+	propositionSchema := map[string]*schema.Schema{
+		"principal": {Type: schema.TypeList, Optional: true},
+		"name":      {Type: schema.TypeString, Required: true},
+		"guid":      {Type: schema.TypeString, Computed: true},
+	}
+	applicationSchema := map[string]*schema.Schema{
+		"name":           {Type: schema.TypeString, Required: true},
+		"proposition_id": {Type: schema.TypeString, Required: true},
+		"description":    {Type: schema.TypeString, Optional: true},
+		"enabled":        {Type: schema.TypeBool, Optional: true, Default: true},
+		"secret":         {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"tags":           {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"service_url": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"url":        {Type: schema.TypeString, Required: true},
+				"sort_order": {Type: schema.TypeInt, Required: true},
+			}},
+		},
+	}
+	objects := []exportObject{
+		{
+			ResourceType: "hsdp_connect_mdm_proposition",
+			Name:         "prop",
+			ID:           "Proposition/1",
+			Schema:       propositionSchema,
+			Values:       map[string]interface{}{"name": "PROP", "guid": "1", "principal": []interface{}{}},
+		},
+		{
+			ResourceType: "hsdp_connect_mdm_application",
+			Name:         "app",
+			ID:           "Application/2",
+			Schema:       applicationSchema,
+			Values: map[string]interface{}{
+				"name":           "APP",
+				"proposition_id": "Proposition/1",
+				"description":    "",
+				"enabled":        false,
+				"secret":         "hidden",
+				"tags":           []interface{}{"a", "Proposition/1"},
+				"service_url": []interface{}{
+					map[string]interface{}{"url": "https://example.com", "sort_order": 1},
+				},
+			},
+		},
+	}
+
+	expected := `import {
+  to = hsdp_connect_mdm_proposition.prop
+  id = "Proposition/1"
+}
+
+resource "hsdp_connect_mdm_proposition" "prop" {
+  name = "PROP"
+}
+
+import {
+  to = hsdp_connect_mdm_application.app
+  id = "Application/2"
+}
+
+resource "hsdp_connect_mdm_application" "app" {
+  enabled        = false
+  name           = "APP"
+  proposition_id = hsdp_connect_mdm_proposition.prop.id
+  tags           = ["a", hsdp_connect_mdm_proposition.prop.id]
+  service_url {
+    sort_order = 1
+    url        = "https://example.com"
+  }
+}
+`
+	assert.Equal(t, expected, renderExport(objects, true))
+
+	withoutImports := renderExport(objects[:1], false)
+	assert.Equal(t, "resource \"hsdp_connect_mdm_proposition\" \"prop\" {\n  name = \"PROP\"\n}\n", withoutImports)
+}
+
+func TestRenderExportSensitive(t *testing.T) {
+	clientSchema := map[string]*schema.Schema{
+		"name":          {Type: schema.TypeString, Required: true},
+		"client_secret": {Type: schema.TypeString, Required: true, Sensitive: true},
+		"token":         {Type: schema.TypeString, Optional: true, Sensitive: true},
+	}
+	objects := []exportObject{
+		{
+			ResourceType: "hsdp_connect_mdm_oauth_client",
+			Name:         "main",
+			ID:           "OAuthClient/1",
+			Schema:       clientSchema,
+			Values:       map[string]interface{}{"name": "MAIN", "client_secret": "", "token": "hidden"},
+		},
+	}
+
+	expected := `variable "oauth_client_main_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "hsdp_connect_mdm_oauth_client" "main" {
+  client_secret = var.oauth_client_main_client_secret
+  name          = "MAIN"
+}
+`
+	assert.Equal(t, expected, renderExport(objects, false))
+}
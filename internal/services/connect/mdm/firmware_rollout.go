@@ -0,0 +1,211 @@
+package mdm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	rolloutInProgress = "InProgress"
+	rolloutPaused     = "Paused"
+	rolloutCancelled  = "Cancelled"
+	rolloutCompleted  = "Completed"
+
+	wavePending    = "Pending"
+	waveInProgress = "InProgress"
+	waveSucceeded  = "Succeeded"
+	waveFailed     = "Failed"
+	waveCancelled  = "Cancelled"
+	waveSkipped    = "Skipped"
+
+	failedWaveRetry = "retry"
+	failedWaveSkip  = "skip"
+
+	distributionActive   = "ACTIVE"
+	distributionCanceled = "CANCELED"
+)
+
+// A rollout that stops at a failed wave leaves a consistent state, so these are reported as warnings
+var (
+	errRolloutPaused    = errors.New("rollout paused")
+	errRolloutCancelled = errors.New("rollout cancelled")
+)
+
+var (
+	defaultSuccessStatuses = []string{"COMPLETED"}
+	defaultFailureStatuses = []string{"FAILED", distributionCanceled}
+)
+
+// rolloutWave is a set of device groups that receive the firmware at the same time
+type rolloutWave struct {
+	DeviceGroupIDs []string
+	RequestIDs     map[string]string
+	Status         string
+	Succeeded      int
+	Failed         int
+}
+
+// planWaves splits the device groups into waves. The canary groups form the first wave, the remaining
+// groups are split by cumulative percentages. Percentages must be increasing and end at 100
+func planWaves(canary, groups []string, percentages []int) ([]rolloutWave, error) {
+	if len(percentages) == 0 {
+		percentages = []int{100}
+	}
+	previous := 0
+	for _, p := range percentages {
+		if p <= previous || p > 100 {
+			return nil, fmt.Errorf("wave_percentages must be increasing values between 1 and 100, got %v", percentages)
+		}
+		previous = p
+	}
+	if previous != 100 {
+		return nil, fmt.Errorf("the last of wave_percentages must be 100, got %d", previous)
+	}
+
+	var waves []rolloutWave
+	isCanary := make(map[string]bool)
+	if len(canary) > 0 {
+		waves = append(waves, newRolloutWave(canary))
+		for _, g := range canary {
+			isCanary[g] = true
+		}
+	}
+	var remaining []string
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		if isCanary[g] || seen[g] {
+			continue
+		}
+		seen[g] = true
+		remaining = append(remaining, g)
+	}
+	start := 0
+	for _, p := range percentages {
+		// Round up so small percentages of few groups still contain a group
+		end := (len(remaining)*p + 99) / 100
+		if end <= start {
+			continue
+		}
+		waves = append(waves, newRolloutWave(remaining[start:end]))
+		start = end
+	}
+	if len(waves) == 0 {
+		return nil, fmt.Errorf("rollout has no device groups")
+	}
+	return waves, nil
+}
+
+func newRolloutWave(groups []string) rolloutWave {
+	return rolloutWave{
+		DeviceGroupIDs: append([]string(nil), groups...),
+		RequestIDs:     make(map[string]string),
+		Status:         wavePending,
+	}
+}
+
+// evaluateWave counts the distribution request statuses of a wave. A wave succeeds once threshold percent
+// of its requests reached a success status, and fails once that is no longer possible
+func evaluateWave(statuses []string, successStatuses, failureStatuses []string, threshold int) (succeeded, failed int, outcome string) {
+	isSuccess := statusSet(successStatuses)
+	isFailure := statusSet(failureStatuses)
+	for _, s := range statuses {
+		switch {
+		case isSuccess[strings.ToUpper(s)]:
+			succeeded++
+		case isFailure[strings.ToUpper(s)]:
+			failed++
+		}
+	}
+	total := len(statuses)
+	required := (total*threshold + 99) / 100
+	switch {
+	case succeeded >= required:
+		return succeeded, failed, waveSucceeded
+	case total-failed < required:
+		return succeeded, failed, waveFailed
+	default:
+		return succeeded, failed, waveInProgress
+	}
+}
+
+// resumeFailedWave prepares a failed wave for the next attempt. With skip the wave is marked as
+// skipped, otherwise the device groups whose distribution request failed are given a new request
+func resumeFailedWave(wave *rolloutWave, statuses []string, failureStatuses []string, skip bool) {
+	if skip {
+		wave.Status = waveSkipped
+		return
+	}
+	isFailure := statusSet(failureStatuses)
+	for i, g := range wave.DeviceGroupIDs {
+		if i < len(statuses) && isFailure[strings.ToUpper(statuses[i])] {
+			delete(wave.RequestIDs, g)
+		}
+	}
+	wave.Status = wavePending
+}
+
+func statusSet(statuses []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, s := range statuses {
+		set[strings.ToUpper(s)] = true
+	}
+	return set
+}
+
+func flattenRolloutWaves(waves []rolloutWave) []interface{} {
+	result := make([]interface{}, 0, len(waves))
+	for i, w := range waves {
+		groups := make([]interface{}, 0, len(w.DeviceGroupIDs))
+		for _, g := range w.DeviceGroupIDs {
+			groups = append(groups, g)
+		}
+		requestIDs := make(map[string]interface{})
+		for g, id := range w.RequestIDs {
+			requestIDs[g] = id
+		}
+		result = append(result, map[string]interface{}{
+			"index":            i,
+			"device_group_ids": groups,
+			"request_ids":      requestIDs,
+			"status":           w.Status,
+			"succeeded":        w.Succeeded,
+			"failed":           w.Failed,
+		})
+	}
+	return result
+}
+
+func expandRolloutWaves(raw []interface{}) []rolloutWave {
+	waves := make([]rolloutWave, 0, len(raw))
+	for _, r := range raw {
+		m := r.(map[string]interface{})
+		w := rolloutWave{
+			RequestIDs: make(map[string]string),
+			Status:     m["status"].(string),
+			Succeeded:  m["succeeded"].(int),
+			Failed:     m["failed"].(int),
+		}
+		for _, g := range m["device_group_ids"].([]interface{}) {
+			w.DeviceGroupIDs = append(w.DeviceGroupIDs, g.(string))
+		}
+		for g, id := range m["request_ids"].(map[string]interface{}) {
+			w.RequestIDs[g] = id.(string)
+		}
+		waves = append(waves, w)
+	}
+	return waves
+}
+
+// requestIDs returns all distribution request IDs of the rollout in a stable order
+func requestIDs(waves []rolloutWave) []string {
+	var ids []string
+	for _, w := range waves {
+		for _, id := range w.RequestIDs {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
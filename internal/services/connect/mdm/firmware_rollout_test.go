@@ -0,0 +1,109 @@
+package mdm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanWaves(t *testing.T) {
+	groups := []string{"g1", "g2", "g3", "g4", "g5", "g6", "g7", "g8", "g9", "g10"}
+
+	waves, err := planWaves([]string{"canary"}, groups, []int{10, 50, 100})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, waves, 4)
+	assert.Equal(t, []string{"canary"}, waves[0].DeviceGroupIDs)
+	assert.Equal(t, []string{"g1"}, waves[1].DeviceGroupIDs)
+	assert.Equal(t, []string{"g2", "g3", "g4", "g5"}, waves[2].DeviceGroupIDs)
+	assert.Equal(t, []string{"g6", "g7", "g8", "g9", "g10"}, waves[3].DeviceGroupIDs)
+	assert.Equal(t, wavePending, waves[3].Status)
+
+	// Canary groups are not rolled out twice and small waves are rounded up
+	waves, err = planWaves([]string{"g1"}, []string{"g1", "g2", "g3"}, []int{10, 20, 100})
+	assert.NoError(t, err)
+	assert.Len(t, waves, 3)
+	assert.Equal(t, []string{"g2"}, waves[1].DeviceGroupIDs)
+	assert.Equal(t, []string{"g3"}, waves[2].DeviceGroupIDs)
+
+	waves, err = planWaves(nil, []string{"g1", "g2"}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, waves, 1)
+
+	_, err = planWaves(nil, groups, []int{50, 40, 100})
+	assert.Error(t, err)
+	_, err = planWaves(nil, groups, []int{50})
+	assert.Error(t, err)
+	_, err = planWaves(nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestEvaluateWave(t *testing.T) {
+	success := []string{"COMPLETED"}
+	failure := []string{"FAILED", "CANCELED"}
+
+	succeeded, failed, outcome := evaluateWave([]string{"completed", "ACTIVE", "FAILED", "COMPLETED"}, success, failure, 50)
+	assert.Equal(t, 2, succeeded)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, waveSucceeded, outcome)
+
+	_, _, outcome = evaluateWave([]string{"COMPLETED", "ACTIVE", "FAILED", "COMPLETED"}, success, failure, 100)
+	assert.Equal(t, waveFailed, outcome)
+
+	_, _, outcome = evaluateWave([]string{"COMPLETED", "ACTIVE", "ACTIVE"}, success, failure, 100)
+	assert.Equal(t, waveInProgress, outcome)
+
+	_, _, outcome = evaluateWave([]string{"ACTIVE", "CANCELED", "ACTIVE"}, success, failure, 60)
+	assert.Equal(t, waveInProgress, outcome)
+
+	_, _, outcome = evaluateWave([]string{"ACTIVE", "CANCELED", "FAILED"}, success, failure, 60)
+	assert.Equal(t, waveFailed, outcome)
+}
+
+func TestResumeFailedWave(t *testing.T) {
+	failure := []string{"FAILED", "CANCELED"}
+	wave := rolloutWave{
+		DeviceGroupIDs: []string{"a", "b", "c"},
+		RequestIDs:     map[string]string{"a": "r1", "b": "r2", "c": "r3"},
+		Status:         waveFailed,
+	}
+	retried := wave
+	retried.RequestIDs = map[string]string{"a": "r1", "b": "r2", "c": "r3"}
+	resumeFailedWave(&retried, []string{"COMPLETED", "failed", "CANCELED"}, failure, false)
+	assert.Equal(t, wavePending, retried.Status)
+	assert.Equal(t, map[string]string{"a": "r1"}, retried.RequestIDs)
+
+	skipped := wave
+	resumeFailedWave(&skipped, []string{"COMPLETED", "FAILED", "FAILED"}, failure, true)
+	assert.Equal(t, waveSkipped, skipped.Status)
+	assert.Len(t, skipped.RequestIDs, 3)
+}
+
+func TestRolloutWavesRoundTrip(t *testing.T) {
+	waves := []rolloutWave{
+		{DeviceGroupIDs: []string{"a"}, RequestIDs: map[string]string{"a": "r1"}, Status: waveSucceeded, Succeeded: 1},
+		{DeviceGroupIDs: []string{"b", "c"}, RequestIDs: map[string]string{"b": "r3", "c": "r2"}, Status: waveInProgress},
+	}
+	assert.Equal(t, waves, expandRolloutWaves(flattenRolloutWaves(waves)))
+	assert.Equal(t, []string{"r1", "r2", "r3"}, requestIDs(waves))
+}
+
+func TestRolloutDiags(t *testing.T) {
+	assert.Empty(t, rolloutDiags(nil))
+
+	interrupted := fmt.Errorf("%w: %w. Apply again to resume", errRolloutPaused, fmt.Errorf("waiting for wave 1: %w", context.DeadlineExceeded))
+	diags := rolloutDiags(interrupted)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Contains(t, diags[0].Detail, "deadline exceeded")
+	}
+	diags = rolloutDiags(fmt.Errorf("%w: cancelling failed: %w", errRolloutCancelled, errors.New("boom")))
+	assert.False(t, diags.HasError())
+
+	assert.True(t, rolloutDiags(errors.New("boom")).HasError())
+}
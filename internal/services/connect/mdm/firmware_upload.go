@@ -0,0 +1,188 @@
+package mdm
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/philips-software/go-dip-api/blr"
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+const (
+	fingerprintAlgorithm        = "SHA-256"
+	artifactEncryptionAlgorithm = "AES-256-GCM"
+	firmwareUploadTimeout       = 30 * time.Minute
+)
+
+// firmwareArtifact is a firmware image prepared for upload
+type firmwareArtifact struct {
+	Name          string
+	SourcePath    string
+	SourceHash    string
+	Hash          string
+	Size          int64
+	DecryptionKey string
+	// encrypted holds the ciphertext of an encrypted image, plain images are read from SourcePath
+	encrypted []byte
+}
+
+// open returns the content as uploaded
+func (a *firmwareArtifact) open() (io.ReadCloser, error) {
+	if a.encrypted != nil {
+		return io.NopCloser(bytes.NewReader(a.encrypted)), nil
+	}
+	return os.Open(a.SourcePath)
+}
+
+// sourceFileHash returns the SHA-256 of the file at path
+func sourceFileHash(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// prepareArtifact hashes the source file and optionally encrypts it with a fresh AES-256 key.
+// Hash and Size describe the content as uploaded, SourceHash describes the source file.
+// Plain images are streamed from disk, AES-GCM needs the whole image so encrypted ones are held in memory
+func prepareArtifact(filePath string, encrypt bool) (*firmwareArtifact, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading source: %w", err)
+	}
+	sourceHash, err := sourceFileHash(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading source: %w", err)
+	}
+	artifact := &firmwareArtifact{
+		Name:       path.Base(strings.ReplaceAll(filePath, "\\", "/")),
+		SourcePath: filePath,
+		SourceHash: sourceHash,
+		Hash:       sourceHash,
+		Size:       info.Size(),
+	}
+	if !encrypt {
+		return artifact, nil
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading source: %w", err)
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating encryption key: %w", err)
+	}
+	if artifact.encrypted, err = encryptArtifact(content, key); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(artifact.encrypted)
+	artifact.Hash = hex.EncodeToString(sum[:])
+	artifact.Size = int64(len(artifact.encrypted))
+	artifact.DecryptionKey = base64.StdEncoding.EncodeToString(key)
+	return artifact, nil
+}
+
+// encryptArtifact encrypts plain with AES-256-GCM. The nonce is prepended to the ciphertext
+func encryptArtifact(plain, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting artifact: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting artifact: %w", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("encrypting artifact: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+// blobPath returns the object path of the artifact in the bucket
+func blobPath(prefix, version, name string) string {
+	return path.Join("/", prefix, version, name)
+}
+
+// uploadArtifact registers the blob in the bucket and uploads the artifact to the pre-signed URL BLR returns for it
+func uploadArtifact(ctx context.Context, client *blr.Client, bucket, objectPath string, artifact *firmwareArtifact) (*blr.Blob, error) {
+	var created *blr.Blob
+	var resp *blr.Response
+	err := tools.TryHTTPCall(ctx, 5, func() (*http.Response, error) {
+		var err error
+		created, resp, err = client.Blobs.Create(blr.Blob{
+			ResourceType: "Blob",
+			Bucket:       bucket,
+			BlobPath:     path.Dir(objectPath),
+			BlobName:     path.Base(objectPath),
+		})
+		if err != nil {
+			_ = client.TokenRefresh()
+		}
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("registering blob: %w", err)
+	}
+	if created == nil || created.UploadURL == "" {
+		return nil, fmt.Errorf("registering blob: response contains no upload URL")
+	}
+	httpClient := &http.Client{Timeout: firmwareUploadTimeout}
+	if err := putArtifact(ctx, httpClient, created.UploadURL, artifact); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// putArtifact streams the artifact to a pre-signed upload URL
+func putArtifact(ctx context.Context, httpClient *http.Client, uploadURL string, artifact *firmwareArtifact) error {
+	err := tools.TryHTTPCall(ctx, 3, func() (*http.Response, error) {
+		body, err := artifact.open()
+		if err != nil {
+			return nil, backoff.Permanent(err)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, body)
+		if err != nil {
+			_ = body.Close()
+			return nil, backoff.Permanent(err)
+		}
+		req.ContentLength = artifact.Size
+		req.Header.Set("Content-Type", "application/octet-stream")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return resp, fmt.Errorf("status %d", resp.StatusCode)
+		}
+		return resp, nil
+	}, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout)
+	if err != nil {
+		return fmt.Errorf("uploading blob: %w", err)
+	}
+	return nil
+}
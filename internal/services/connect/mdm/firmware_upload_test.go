@@ -0,0 +1,113 @@
+package mdm
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareArtifact(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "firmware.bin")
+	content := []byte("firmware image")
+	assert.NoError(t, os.WriteFile(source, content, 0600))
+	sum := sha256.Sum256(content)
+
+	hash, err := sourceFileHash(source)
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), hash)
+
+	plain, err := prepareArtifact(source, false)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "firmware.bin", plain.Name)
+	assert.Equal(t, hash, plain.SourceHash)
+	assert.Equal(t, hash, plain.Hash)
+	assert.Equal(t, int64(len(content)), plain.Size)
+	assert.Empty(t, plain.DecryptionKey)
+	assert.Nil(t, plain.encrypted)
+
+	encrypted, err := prepareArtifact(source, true)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, hash, encrypted.SourceHash)
+	assert.NotEqual(t, hash, encrypted.Hash)
+	assert.Equal(t, int64(len(encrypted.encrypted)), encrypted.Size)
+	sum = sha256.Sum256(encrypted.encrypted)
+	assert.Equal(t, hex.EncodeToString(sum[:]), encrypted.Hash)
+
+	key, err := base64.StdEncoding.DecodeString(encrypted.DecryptionKey)
+	assert.NoError(t, err)
+	block, _ := aes.NewCipher(key)
+	gcm, _ := cipher.NewGCM(block)
+	nonce, ciphertext := encrypted.encrypted[:gcm.NonceSize()], encrypted.encrypted[gcm.NonceSize():]
+	decrypted, err := gcm.Open(nil, nonce, ciphertext, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, content, decrypted)
+
+	_, err = prepareArtifact(filepath.Join(t.TempDir(), "missing.bin"), false)
+	assert.Error(t, err)
+}
+
+func TestBlobPaths(t *testing.T) {
+	assert.Equal(t, "/firmware/1.0.0/fw.bin", blobPath("firmware", "1.0.0", "fw.bin"))
+	assert.Equal(t, "/release/1.0.0/fw.bin", blobPath("/release/", "1.0.0", "fw.bin"))
+}
+
+func TestPutArtifact(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "fw.bin")
+	assert.NoError(t, os.WriteFile(source, []byte("content"), 0600))
+	plain, _ := prepareArtifact(source, false)
+	encrypted, _ := prepareArtifact(source, true)
+
+	var uploaded []byte
+	var length int64
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky":
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		case "/denied":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		length = r.ContentLength
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	err := putArtifact(context.Background(), server.Client(), server.URL+"/presigned", plain)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), uploaded)
+	assert.Equal(t, int64(7), length)
+
+	err = putArtifact(context.Background(), server.Client(), server.URL+"/presigned", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, encrypted.encrypted, uploaded)
+	assert.Equal(t, encrypted.Size, length)
+
+	uploaded = nil
+	err = putArtifact(context.Background(), server.Client(), server.URL+"/flaky", plain)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("content"), uploaded)
+
+	err = putArtifact(context.Background(), server.Client(), server.URL+"/denied", plain)
+	assert.ErrorContains(t, err, "status 403")
+}
@@ -26,6 +26,7 @@ func ResourceMDMApplication() *schema.Resource {
 		DeleteContext: resourceMDMApplicationDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -122,7 +123,8 @@ func applicationToSchema(resource mdm.Application, d *schema.ResourceData) {
 func resourceMDMApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -182,7 +184,8 @@ func resourceMDMApplicationRead(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 
 	c := m.(*config.Config)
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -227,7 +230,8 @@ func resourceMDMApplicationUpdate(_ context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 
 	c := m.(*config.Config)
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -24,6 +24,7 @@ func ResourceConnectMDMAuthenticationMethod() *schema.Resource {
 		DeleteContext: resourceConnectMDMAuthenticationMethodDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -38,18 +39,41 @@ func ResourceConnectMDMAuthenticationMethod() *schema.Resource {
 				Required: true,
 			},
 			"password": {
-				Type:      schema.TypeString,
-				Sensitive: true,
-				Required:  true,
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Optional:     true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Optional:     true,
+				WriteOnly:    true,
+				RequiredWith: []string{"secrets_wo_version"},
+				Description:  "Write-only variant of password which is not persisted in the state.",
 			},
 			"client_id": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"client_secret": {
-				Type:      schema.TypeString,
-				Sensitive: true,
-				Required:  true,
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Optional:     true,
+				ExactlyOneOf: []string{"client_secret", "client_secret_wo"},
+			},
+			"client_secret_wo": {
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Optional:     true,
+				WriteOnly:    true,
+				RequiredWith: []string{"secrets_wo_version"},
+				Description:  "Write-only variant of client_secret which is not persisted in the state.",
+			},
+			"secrets_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Version of the write-only secrets. Change it to send updated password_wo and client_secret_wo values.",
 			},
 			"auth_method": {
 				Type:     schema.TypeString,
@@ -70,10 +94,24 @@ func ResourceConnectMDMAuthenticationMethod() *schema.Resource {
 					tools.SuppressWhenGenerated,
 					tools.SuppressDefaultSystemValue),
 			},
+			"check_connectivity": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Request a token from auth_url with the configured credentials on every refresh, or on create and update when write-only secrets are used.",
+			},
+			"connectivity_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The result of the last connectivity check.",
+			},
 			"version_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -89,8 +127,14 @@ func schemaToAuthenticationMethod(d *schema.ResourceData) mdm.AuthenticationMeth
 	organizationId := d.Get("organization_id").(string)
 	clientId := d.Get("client_id").(string)
 	clientSecret := d.Get("client_secret").(string)
+	if secret := writeOnlyString(d, "client_secret_wo"); secret != "" {
+		clientSecret = secret
+	}
 	loginName := d.Get("login_name").(string)
 	password := d.Get("password").(string)
+	if secret := writeOnlyString(d, "password_wo"); secret != "" {
+		password = secret
+	}
 	authURL := d.Get("auth_url").(string)
 	authMethod := d.Get("auth_method").(string)
 
@@ -120,6 +164,7 @@ func schemaToAuthenticationMethod(d *schema.ResourceData) mdm.AuthenticationMeth
 }
 
 func AuthenticationMethodToSchema(resource mdm.AuthenticationMethod, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("login_name", resource.LoginName)
@@ -145,7 +190,8 @@ func AuthenticationMethodToSchema(resource mdm.AuthenticationMethod, d *schema.R
 func resourceConnectMDMAuthenticationMethodCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -173,7 +219,11 @@ func resourceConnectMDMAuthenticationMethodCreate(ctx context.Context, d *schema
 	}
 	_ = d.Set("guid", created.ID)
 	d.SetId(fmt.Sprintf("AuthenticationMethod/%s", created.ID))
-	return resourceConnectMDMAuthenticationMethodRead(ctx, d, m)
+	var diags diag.Diagnostics
+	if len(writeOnlySecretFields(d)) > 0 {
+		diags = checkAuthenticationMethod(ctx, d, resource)
+	}
+	return append(diags, resourceConnectMDMAuthenticationMethodRead(ctx, d, m)...)
 }
 
 func resourceConnectMDMAuthenticationMethodRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -181,7 +231,8 @@ func resourceConnectMDMAuthenticationMethodRead(ctx context.Context, d *schema.R
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -208,33 +259,72 @@ func resourceConnectMDMAuthenticationMethodRead(ctx context.Context, d *schema.R
 		}
 		return diag.FromErr(err)
 	}
+	writeOnlyFields := writeOnlySecretFields(d)
 	AuthenticationMethodToSchema(*resource, d)
+	for _, field := range writeOnlyFields {
+		// Write-only secrets are not persisted
+		_ = d.Set(field, "")
+	}
+	// Write-only secrets are only available during apply, so those are checked by create and update instead
+	if len(writeOnlyFields) == 0 {
+		diags = append(diags, checkAuthenticationMethod(ctx, d, *resource)...)
+	}
 	return diags
 }
 
+// writeOnlySecretFields returns the secret fields which are set through their _wo variant. Those fields are
+// empty in the state, while fields set directly hold the configured secret
+func writeOnlySecretFields(d *schema.ResourceData) []string {
+	var fields []string
+	if d.Get("secrets_wo_version").(int) == 0 {
+		return fields
+	}
+	for _, field := range []string{"password", "client_secret"} {
+		if d.Get(field).(string) == "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
 func resourceConnectMDMAuthenticationMethodUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.AuthenticationMethods.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMAuthenticationMethod(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		AuthenticationMethodToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	resource := schemaToAuthenticationMethod(d)
 	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.AuthenticationMethods.Update(resource)
+	_, resp, err := client.AuthenticationMethods.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
 	}
-	return resourceConnectMDMAuthenticationMethodRead(ctx, d, m)
+	if len(writeOnlySecretFields(d)) > 0 {
+		diags = checkAuthenticationMethod(ctx, d, resource)
+	}
+	return append(diags, resourceConnectMDMAuthenticationMethodRead(ctx, d, m)...)
 }
 
 func resourceConnectMDMAuthenticationMethodDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -242,7 +332,8 @@ func resourceConnectMDMAuthenticationMethodDelete(_ context.Context, d *schema.R
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
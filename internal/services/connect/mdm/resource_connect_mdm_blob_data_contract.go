@@ -23,6 +23,7 @@ func ResourceConnectMDMBlobDataContract() *schema.Resource {
 		DeleteContext: resourceConnectMDMBlobDataContractDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -62,6 +63,10 @@ func ResourceConnectMDMBlobDataContract() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -92,6 +97,7 @@ func schemaToBlobDataContract(d *schema.ResourceData) mdm.BlobDataContract {
 }
 
 func blobDataContractToSchema(resource mdm.BlobDataContract, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("root_path_in_bucket", resource.RootPathInBucket)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("data_type_id", resource.DataTypeID.Reference)
@@ -105,7 +111,8 @@ func blobDataContractToSchema(resource mdm.BlobDataContract, d *schema.ResourceD
 func resourceConnectMDMBlobDataContractCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -141,7 +148,8 @@ func resourceConnectMDMBlobDataContractRead(ctx context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -177,19 +185,31 @@ func resourceConnectMDMBlobDataContractUpdate(ctx context.Context, d *schema.Res
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.BlobDataContracts.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMBlobDataContract(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		blobDataContractToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	resource := schemaToBlobDataContract(d)
 	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.BlobDataContracts.Update(resource)
+	_, resp, err := client.BlobDataContracts.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -202,7 +222,8 @@ func resourceConnectMDMBlobDataContractDelete(_ context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
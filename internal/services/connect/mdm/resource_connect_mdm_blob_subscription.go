@@ -24,6 +24,7 @@ func ResourceConnectMDMBlobSubscription() *schema.Resource {
 		DeleteContext: resourceConnectMDMBlobSubscriptionDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -47,6 +48,10 @@ func ResourceConnectMDMBlobSubscription() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -81,6 +86,7 @@ func schemaToBlobSubscription(d *schema.ResourceData) mdm.BlobSubscription {
 }
 
 func blobSubscriptionToSchema(resource mdm.BlobSubscription, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("data_type_id", resource.DataTypeId.Reference)
@@ -97,7 +103,8 @@ func blobSubscriptionToSchema(resource mdm.BlobSubscription, d *schema.ResourceD
 func resourceConnectMDMBlobSubscriptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -133,7 +140,8 @@ func resourceConnectMDMBlobSubscriptionRead(ctx context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -169,19 +177,31 @@ func resourceConnectMDMBlobSubscriptionUpdate(ctx context.Context, d *schema.Res
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.BlobSubscriptions.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMBlobSubscription(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		blobSubscriptionToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	resource := schemaToBlobSubscription(d)
 	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.BlobSubscriptions.Update(resource)
+	_, resp, err := client.BlobSubscriptions.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -194,7 +214,8 @@ func resourceConnectMDMBlobSubscriptionDelete(_ context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
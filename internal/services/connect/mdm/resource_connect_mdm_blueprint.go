@@ -0,0 +1,502 @@
+package mdm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func ResourceConnectMDMBlueprint() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConnectMDMBlueprintCreate,
+		ReadContext:   resourceConnectMDMBlueprintRead,
+		UpdateContext: resourceConnectMDMBlueprintUpdate,
+		DeleteContext: resourceConnectMDMBlueprintDelete,
+		CustomizeDiff: resourceConnectMDMBlueprintCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
+			"spec": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"object_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"objects": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// blueprintEntry is the state of an MDM object managed by a blueprint
+type blueprintEntry struct {
+	Kind       string                 `json:"kind"`
+	Name       string                 `json:"name"`
+	ID         string                 `json:"id"`
+	Hash       string                 `json:"hash"`
+	Spec       map[string]interface{} `json:"spec"`
+	Attributes map[string]string      `json:"attributes"`
+}
+
+func (e blueprintEntry) key() string {
+	return e.Kind + "." + e.Name
+}
+
+// blueprintState holds the managed objects in the order they were created
+type blueprintState struct {
+	entries []*blueprintEntry
+}
+
+func loadBlueprintState(raw string) (*blueprintState, error) {
+	s := &blueprintState{}
+	if raw == "" {
+		return s, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &s.entries); err != nil {
+		return nil, fmt.Errorf("corrupt blueprint state: %w", err)
+	}
+	return s, nil
+}
+
+func (s *blueprintState) get(key string) *blueprintEntry {
+	for _, e := range s.entries {
+		if e.key() == key {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *blueprintState) remove(key string) {
+	for i, e := range s.entries {
+		if e.key() == key {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+// insert adds the entry at index, keeping the creation order of the other entries
+func (s *blueprintState) insert(index int, entry *blueprintEntry) {
+	if index < 0 || index > len(s.entries) {
+		index = len(s.entries)
+	}
+	s.entries = append(s.entries[:index], append([]*blueprintEntry{entry}, s.entries[index:]...)...)
+}
+
+func (s *blueprintState) index(key string) int {
+	for i, e := range s.entries {
+		if e.key() == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *blueprintState) lookup(key, attribute string) (string, bool) {
+	e := s.get(key)
+	if e == nil {
+		return "", false
+	}
+	if attribute == "id" {
+		return e.ID, true
+	}
+	v, ok := e.Attributes[attribute]
+	return v, ok
+}
+
+func (s *blueprintState) save(d *schema.ResourceData) {
+	ids := make(map[string]string)
+	for _, e := range s.entries {
+		ids[e.key()] = e.ID
+	}
+	data, _ := json.Marshal(s.entries)
+	_ = d.Set("objects", string(data))
+	_ = d.Set("object_ids", ids)
+}
+
+func diagsError(diags diag.Diagnostics) error {
+	var msgs []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			msgs = append(msgs, strings.TrimSpace(d.Summary+" "+d.Detail))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+// blueprintObjectData returns the resource and the resource data of an object, based on its stored state
+func blueprintObjectData(kind string, entry *blueprintEntry) (*schema.Resource, *schema.ResourceData) {
+	r := blueprintKinds[kind].resource()
+	if entry == nil {
+		return r, r.Data(nil)
+	}
+	return r, r.Data(blueprintInstanceState(entry))
+}
+
+func blueprintInstanceState(entry *blueprintEntry) *terraform.InstanceState {
+	if entry == nil {
+		return nil
+	}
+	return &terraform.InstanceState{ID: entry.ID, Attributes: entry.Attributes}
+}
+
+// planBlueprintObject plans the change from the stored state of an object to the resolved spec, the way Terraform
+// plans a resource. Schema defaults, ForceNew and the CustomizeDiff of the object resource apply, and attributes
+// dropped from the spec are removed. The returned resource data is ready to be passed to create or update
+func planBlueprintObject(ctx context.Context, o blueprintObject, entry *blueprintEntry, spec map[string]interface{}, principal interface{}, m interface{}) (*schema.Resource, *schema.ResourceData, *terraform.InstanceDiff, error) {
+	r := blueprintKinds[o.Kind].resource()
+	values := make(map[string]interface{}, len(spec)+1)
+	for k, v := range spec {
+		value, err := coerceValue(r.Schema[k], v)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%s.%s: %w", o.key(), k, err)
+		}
+		if value != nil {
+			values[k] = value
+		}
+	}
+	if err := applySchemaDefaults(r, values); err != nil {
+		return nil, nil, nil, fmt.Errorf("%s: %w", o.key(), err)
+	}
+	values["principal"] = principal
+	state := blueprintInstanceState(entry)
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(values), m)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("planning %s: %w", o.key(), err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("planning %s: %w", o.key(), err)
+	}
+	return r, d, diff, nil
+}
+
+func createBlueprintObject(ctx context.Context, o blueprintObject, spec map[string]interface{}, principal interface{}, m interface{}) (*blueprintEntry, error) {
+	r, d, _, err := planBlueprintObject(ctx, o, nil, spec, principal, m)
+	if err != nil {
+		return nil, err
+	}
+	if err := diagsError(r.CreateContext(ctx, d, m)); err != nil {
+		if d.Id() != "" {
+			_ = r.DeleteContext(ctx, d, m)
+		}
+		return nil, fmt.Errorf("creating %s: %w", o.key(), err)
+	}
+	return &blueprintEntry{
+		Kind:       o.Kind,
+		Name:       o.Name,
+		ID:         d.Id(),
+		Spec:       spec,
+		Attributes: d.State().Attributes,
+	}, nil
+}
+
+// updateBlueprintObject updates the object in place, or replaces it when an attribute that forces a new resource
+// changed. The returned bool reports whether the previous object was deleted, which is also the case when creating
+// its replacement failed
+func updateBlueprintObject(ctx context.Context, o blueprintObject, entry *blueprintEntry, spec map[string]interface{}, principal interface{}, m interface{}) (*blueprintEntry, bool, error) {
+	r, d, diff, err := planBlueprintObject(ctx, o, entry, spec, principal, m)
+	if err != nil {
+		return nil, false, err
+	}
+	if r.UpdateContext == nil || diff.RequiresNew() {
+		if err := deleteBlueprintObject(ctx, entry, m); err != nil {
+			return nil, false, err
+		}
+		created, err := createBlueprintObject(ctx, o, spec, principal, m)
+		return created, true, err
+	}
+	if err := diagsError(r.UpdateContext(ctx, d, m)); err != nil {
+		return nil, false, fmt.Errorf("updating %s: %w", o.key(), err)
+	}
+	return &blueprintEntry{
+		Kind:       o.Kind,
+		Name:       o.Name,
+		ID:         d.Id(),
+		Spec:       spec,
+		Attributes: d.State().Attributes,
+	}, false, nil
+}
+
+func deleteBlueprintObject(ctx context.Context, entry *blueprintEntry, m interface{}) error {
+	r, d := blueprintObjectData(entry.Kind, entry)
+	if err := diagsError(r.DeleteContext(ctx, d, m)); err != nil {
+		return fmt.Errorf("deleting %s: %w", entry.key(), err)
+	}
+	return nil
+}
+
+// blueprintChange records a change made during a reconcile. previous is nil for created objects
+type blueprintChange struct {
+	object   blueprintObject
+	index    int
+	previous *blueprintEntry
+}
+
+// revertBlueprintChange undoes a change. Created objects are deleted, updated and replaced objects
+// are brought back to their previous spec
+func revertBlueprintChange(ctx context.Context, state *blueprintState, change blueprintChange, principal interface{}, m interface{}) error {
+	key := change.object.key()
+	current := state.get(key)
+	switch {
+	case change.previous == nil:
+		if current == nil {
+			return nil
+		}
+		if err := deleteBlueprintObject(ctx, current, m); err != nil {
+			return err
+		}
+		state.remove(key)
+	case current == nil:
+		restored, err := createBlueprintObject(ctx, change.object, change.previous.Spec, principal, m)
+		if err != nil {
+			return err
+		}
+		restored.Hash = change.previous.Hash
+		state.insert(change.index, restored)
+	default:
+		restored, deleted, err := updateBlueprintObject(ctx, change.object, current, change.previous.Spec, principal, m)
+		if err != nil {
+			if deleted {
+				state.remove(key)
+			}
+			return err
+		}
+		restored.Hash = change.previous.Hash
+		*current = *restored
+	}
+	return nil
+}
+
+// reconcileBlueprint creates, updates and deletes MDM objects until they match the spec. When a create, update
+// or replace fails, the changes made before are reverted in reverse order
+func reconcileBlueprint(ctx context.Context, d *schema.ResourceData, m interface{}, state *blueprintState) error {
+	objects, err := parseBlueprint(d.Get("spec").(string))
+	if err != nil {
+		return err
+	}
+	principal := d.Get("principal")
+
+	var changes []blueprintChange
+	rollback := func(cause error) error {
+		var errs []string
+		for i := len(changes) - 1; i >= 0; i-- {
+			if err := revertBlueprintChange(ctx, state, changes[i], principal, m); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%w, rollback failed: %s", cause, strings.Join(errs, "; "))
+		}
+		return fmt.Errorf("%w, rolled back %d change(s)", cause, len(changes))
+	}
+
+	wanted := make(map[string]bool)
+	for _, o := range objects {
+		wanted[o.key()] = true
+		if err := validateBlueprintObject(o); err != nil {
+			return rollback(err)
+		}
+		resolved, err := resolveReferences(o.Attributes, state.lookup)
+		if err != nil {
+			return rollback(fmt.Errorf("%s: %w", o.key(), err))
+		}
+		spec := resolved.(map[string]interface{})
+		hash := hashAttributes(spec)
+
+		existing := state.get(o.key())
+		switch {
+		case existing == nil:
+			entry, err := createBlueprintObject(ctx, o, spec, principal, m)
+			if err != nil {
+				return rollback(err)
+			}
+			entry.Hash = hash
+			state.entries = append(state.entries, entry)
+			changes = append(changes, blueprintChange{object: o, index: len(state.entries) - 1})
+		case existing.Hash != hash:
+			previous := *existing
+			change := blueprintChange{object: o, index: state.index(o.key()), previous: &previous}
+			entry, deleted, err := updateBlueprintObject(ctx, o, existing, spec, principal, m)
+			if err != nil {
+				if deleted {
+					// The object is gone, rollback creates it again
+					state.remove(o.key())
+					changes = append(changes, change)
+				}
+				return rollback(err)
+			}
+			entry.Hash = hash
+			*existing = *entry
+			changes = append(changes, change)
+		}
+	}
+
+	for i := len(state.entries) - 1; i >= 0; i-- {
+		entry := state.entries[i]
+		if wanted[entry.key()] {
+			continue
+		}
+		if err := deleteBlueprintObject(ctx, entry, m); err != nil {
+			return err
+		}
+		state.remove(entry.key())
+	}
+	return nil
+}
+
+// resourceConnectMDMBlueprintCustomizeDiff validates the spec and plans a reconcile when objects drifted
+func resourceConnectMDMBlueprintCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("spec") {
+		return nil
+	}
+	objects, err := parseBlueprint(d.Get("spec").(string))
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		if err := validateBlueprintObject(o); err != nil {
+			return err
+		}
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	reconcile := d.HasChange("spec")
+	state, err := loadBlueprintState(d.Get("objects").(string))
+	if err != nil {
+		return err
+	}
+	for _, o := range objects {
+		if e := state.get(o.key()); e == nil || e.Hash == "" {
+			reconcile = true
+		}
+	}
+	if !reconcile {
+		return nil
+	}
+	if err := d.SetNewComputed("objects"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("object_ids")
+}
+
+func resourceConnectMDMBlueprintCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	state := &blueprintState{}
+	if err := reconcileBlueprint(ctx, d, m, state); err != nil {
+		if len(state.entries) > 0 {
+			// Rollback was incomplete, keep track of what is left
+			d.SetId(uuid.NewString())
+			state.save(d)
+		}
+		return diag.FromErr(err)
+	}
+	d.SetId(uuid.NewString())
+	state.save(d)
+	return resourceConnectMDMBlueprintRead(ctx, d, m)
+}
+
+func resourceConnectMDMBlueprintRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	state, err := loadBlueprintState(d.Get("objects").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, entry := range append([]*blueprintEntry(nil), state.entries...) {
+		r, od := blueprintObjectData(entry.Kind, entry)
+		if err := diagsError(r.ReadContext(ctx, od, m)); err != nil {
+			return diag.FromErr(fmt.Errorf("reading %s: %w", entry.key(), err))
+		}
+		if od.Id() == "" {
+			state.remove(entry.key())
+			continue
+		}
+		entry.Attributes = od.State().Attributes
+		if blueprintObjectDrifted(r, od, entry.Spec) {
+			entry.Hash = ""
+		}
+	}
+	state.save(d)
+	return diags
+}
+
+// blueprintObjectDrifted reports whether a primitive attribute of the object no longer matches the spec
+func blueprintObjectDrifted(r *schema.Resource, d *schema.ResourceData, spec map[string]interface{}) bool {
+	for k, v := range spec {
+		s := r.Schema[k]
+		if s == nil || s.Sensitive {
+			continue
+		}
+		switch s.Type {
+		case schema.TypeString, schema.TypeInt, schema.TypeBool, schema.TypeFloat:
+			want, err := coerceValue(s, v)
+			if err != nil {
+				continue
+			}
+			if fmt.Sprint(want) != fmt.Sprint(d.Get(k)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func resourceConnectMDMBlueprintUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// objects is unknown in the plan when a reconcile is due, continue from the state
+	previous, _ := d.GetChange("objects")
+	state, err := loadBlueprintState(previous.(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = reconcileBlueprint(ctx, d, m, state)
+	state.save(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceConnectMDMBlueprintRead(ctx, d, m)
+}
+
+func resourceConnectMDMBlueprintDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	state, err := loadBlueprintState(d.Get("objects").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for i := len(state.entries) - 1; i >= 0; i-- {
+		entry := state.entries[i]
+		if err := deleteBlueprintObject(ctx, entry, m); err != nil {
+			state.save(d)
+			return diag.FromErr(err)
+		}
+		state.remove(entry.key())
+	}
+	d.SetId("")
+	return nil
+}
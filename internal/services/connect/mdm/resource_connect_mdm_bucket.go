@@ -24,6 +24,7 @@ func ResourceConnectMDMBucket() *schema.Resource {
 		DeprecationMessage: "Use the hsdp_blr_bucket resource to manage buckets.",
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -82,6 +83,10 @@ func ResourceConnectMDMBucket() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -171,6 +176,7 @@ func schemaToBucket(d *schema.ResourceData) mdm.Bucket {
 }
 
 func bucketToSchema(resource mdm.Bucket, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("proposition_id", resource.PropositionID)
@@ -201,7 +207,8 @@ func bucketToSchema(resource mdm.Bucket, d *schema.ResourceData) {
 func resourceConnectMDMBucketCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -238,7 +245,8 @@ func resourceConnectMDMBucketRead(ctx context.Context, d *schema.ResourceData, m
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -274,19 +282,31 @@ func resourceConnectMDMBucketUpdate(ctx context.Context, d *schema.ResourceData,
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.Buckets.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMBucket(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		bucketToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	resource := schemaToBucket(d)
 	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.Buckets.Update(resource)
+	_, resp, err := client.Buckets.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -299,7 +319,8 @@ func resourceConnectMDMBucketDelete(_ context.Context, d *schema.ResourceData, m
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -23,6 +23,7 @@ func ResourceConnectMDMDataType() *schema.Resource {
 		DeleteContext: resourceConnectMDMDataTypeDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -47,6 +48,10 @@ func ResourceConnectMDMDataType() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -71,6 +76,7 @@ func schemaToDataType(d *schema.ResourceData) mdm.DataType {
 }
 
 func dataTypeToSchema(resource mdm.DataType, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
@@ -82,7 +88,8 @@ func dataTypeToSchema(resource mdm.DataType, d *schema.ResourceData) {
 func resourceConnectMDMDataTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -118,7 +125,8 @@ func resourceConnectMDMDataTypeRead(ctx context.Context, d *schema.ResourceData,
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -154,19 +162,31 @@ func resourceConnectMDMDataTypeUpdate(ctx context.Context, d *schema.ResourceDat
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.DataTypes.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMDataType(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		dataTypeToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	service := schemaToDataType(d)
 	service.ID = id
+	service.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.DataTypes.Update(service)
+	_, resp, err := client.DataTypes.Update(service)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -179,7 +199,8 @@ func resourceConnectMDMDataTypeDelete(_ context.Context, d *schema.ResourceData,
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
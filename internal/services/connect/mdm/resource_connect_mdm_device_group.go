@@ -24,6 +24,7 @@ func ResourceConnectMDMDeviceGroup() *schema.Resource {
 		DeleteContext: resourceConnectMDMDeviceGroupDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -48,6 +49,10 @@ func ResourceConnectMDMDeviceGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -82,6 +87,7 @@ func schemaToDeviceGroup(d *schema.ResourceData) mdm.DeviceGroup {
 }
 
 func deviceGroupToSchema(resource mdm.DeviceGroup, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("application_id", resource.ApplicationId.Reference)
@@ -100,7 +106,8 @@ func deviceGroupToSchema(resource mdm.DeviceGroup, d *schema.ResourceData) {
 func resourceConnectMDMDeviceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -136,7 +143,8 @@ func resourceConnectMDMDeviceGroupRead(ctx context.Context, d *schema.ResourceDa
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -172,19 +180,31 @@ func resourceConnectMDMDeviceGroupUpdate(ctx context.Context, d *schema.Resource
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.DeviceGroups.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMDeviceGroup(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		deviceGroupToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	resource := schemaToDeviceGroup(d)
 	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.DeviceGroups.Update(resource)
+	_, resp, err := client.DeviceGroups.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -197,7 +217,8 @@ func resourceConnectMDMDeviceGroupDelete(_ context.Context, d *schema.ResourceDa
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
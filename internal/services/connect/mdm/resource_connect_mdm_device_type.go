@@ -23,8 +23,10 @@ func ResourceConnectMDMDeviceType() *schema.Resource {
 		ReadContext:   resourceConnectMDMDeviceTypeRead,
 		UpdateContext: resourceConnectMDMDeviceTypeUpdate,
 		DeleteContext: resourceConnectMDMDeviceTypeDelete,
+		CustomizeDiff: customizeDeviceTypeDiff,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -51,13 +53,28 @@ func ResourceConnectMDMDeviceType() *schema.Resource {
 					tools.SuppressDefaultSystemValue),
 			},
 			"custom_type_attributes": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:             schema.TypeMap,
+				Optional:         true,
+				ConflictsWith:    []string{"custom_type_attributes_json"},
+				ValidateDiagFunc: validateCustomTypeAttributes,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+			},
+			"custom_type_attributes_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"custom_type_attributes"},
+				ValidateDiagFunc: validateCustomTypeAttributesJSON,
+				DiffSuppressFunc: suppressEquivalentCustomTypeAttributes,
+				Description:      "Type attributes as a JSON object. Use this instead of custom_type_attributes for values which are not strings.",
 			},
 			"version_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -90,7 +107,11 @@ func schemaToDeviceType(d *schema.ResourceData) mdm.DeviceType {
 		}
 		resource.DefaultGroupGuid = &identifier
 	}
-	if e, ok := d.GetOk("custom_type_attributes"); ok {
+	if e, ok := d.GetOk("custom_type_attributes_json"); ok {
+		if normalized, err := normalizeCustomTypeAttributes(e.(string)); err == nil {
+			resource.CustomTypeAttributes = json.RawMessage(normalized)
+		}
+	} else if e, ok := d.GetOk("custom_type_attributes"); ok {
 		custom := make(map[string]string)
 		if env, ok := e.(map[string]interface{}); ok {
 			for k, v := range env {
@@ -105,7 +126,8 @@ func schemaToDeviceType(d *schema.ResourceData) mdm.DeviceType {
 	return resource
 }
 
-func DeviceTypeToSchema(resource mdm.DeviceType, d *schema.ResourceData) {
+func DeviceTypeToSchema(resource mdm.DeviceType, d *schema.ResourceData) error {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("device_group_id", resource.DeviceGroupId.Reference)
@@ -118,16 +140,14 @@ func DeviceTypeToSchema(resource mdm.DeviceType, d *schema.ResourceData) {
 		}
 		_ = d.Set("default_iam_group_id", value)
 	}
-	var custom map[string]interface{}
-	if err := json.Unmarshal(resource.CustomTypeAttributes, &custom); err == nil {
-		_ = d.Set("custom_type_attributes", custom)
-	}
+	return customTypeAttributesToSchema(resource.CustomTypeAttributes, d)
 }
 
 func resourceConnectMDMDeviceTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -163,7 +183,8 @@ func resourceConnectMDMDeviceTypeRead(ctx context.Context, d *schema.ResourceDat
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -187,7 +208,9 @@ func resourceConnectMDMDeviceTypeRead(ctx context.Context, d *schema.ResourceDat
 		}
 		return diag.FromErr(err)
 	}
-	DeviceTypeToSchema(*resource, d)
+	if err := DeviceTypeToSchema(*resource, d); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
@@ -196,19 +219,32 @@ func resourceConnectMDMDeviceTypeUpdate(ctx context.Context, d *schema.ResourceD
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.DeviceTypes.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMDeviceType(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		// Attributes which can not be decoded are overwritten by the update
+		_ = DeviceTypeToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	resource := schemaToDeviceType(d)
 	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.DeviceTypes.Update(resource)
+	_, resp, err := client.DeviceTypes.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -221,7 +257,8 @@ func resourceConnectMDMDeviceTypeDelete(_ context.Context, d *schema.ResourceDat
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -23,6 +23,7 @@ func ResourceConnectMDMFirmwareComponent() *schema.Resource {
 		DeleteContext: resourceConnectMDMFirmwareComponentDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -46,6 +47,10 @@ func ResourceConnectMDMFirmwareComponent() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -70,6 +75,7 @@ func schemaToFirmwareComponent(d *schema.ResourceData) mdm.FirmwareComponent {
 }
 
 func FirmwareComponentToSchema(resource mdm.FirmwareComponent, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("device_type_id", resource.DeviceTypeId.Reference)
@@ -80,7 +86,8 @@ func FirmwareComponentToSchema(resource mdm.FirmwareComponent, d *schema.Resourc
 func resourceConnectMDMFirmwareComponentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -116,7 +123,8 @@ func resourceConnectMDMFirmwareComponentRead(ctx context.Context, d *schema.Reso
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -152,19 +160,31 @@ func resourceConnectMDMFirmwareComponentUpdate(ctx context.Context, d *schema.Re
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.FirmwareComponents.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMFirmwareComponent(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		FirmwareComponentToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	service := schemaToFirmwareComponent(d)
 	service.ID = id
+	service.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.FirmwareComponents.Update(service)
+	_, resp, err := client.FirmwareComponents.Update(service)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -177,7 +197,8 @@ func resourceConnectMDMFirmwareComponentDelete(_ context.Context, d *schema.Reso
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -21,8 +21,10 @@ func ResourceConnectMDMFirmwareComponentVersion() *schema.Resource {
 		ReadContext:   resourceConnectMDMFirmwareComponentVersionRead,
 		UpdateContext: resourceConnectMDMFirmwareComponentVersionUpdate,
 		DeleteContext: resourceConnectMDMFirmwareComponentVersionDelete,
+		CustomizeDiff: resourceConnectMDMFirmwareComponentVersionCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"version": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -41,26 +43,65 @@ func ResourceConnectMDMFirmwareComponentVersion() *schema.Resource {
 				Required: true,
 			},
 			"blob_url": {
-				Type:     schema.TypeString,
-				Default:  true,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"source"},
 			},
 			"size": {
-				Type:     schema.TypeInt,
-				Default:  true,
-				Optional: true,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"source"},
 			},
 			"encryption_info": {
-				Type:     schema.TypeSet,
-				MaxItems: 1,
-				Optional: true,
-				Elem:     encryptionInfoSchema(),
+				Type:          schema.TypeSet,
+				MaxItems:      1,
+				Optional:      true,
+				Computed:      true,
+				Elem:          encryptionInfoSchema(),
+				ConflictsWith: []string{"source"},
 			},
 			"fingerprint": {
-				Type:     schema.TypeSet,
-				MaxItems: 1,
-				Optional: true,
-				Elem:     fingerprintSchema(),
+				Type:          schema.TypeSet,
+				MaxItems:      1,
+				Optional:      true,
+				Computed:      true,
+				Elem:          fingerprintSchema(),
+				ConflictsWith: []string{"source"},
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"upload"},
+			},
+			"upload": {
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				RequiredWith: []string{"source"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"path_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "firmware",
+						},
+						"encrypt": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"source_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"component_required": {
 				Type:     schema.TypeBool,
@@ -79,6 +120,10 @@ func ResourceConnectMDMFirmwareComponentVersion() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -114,8 +159,9 @@ func encryptionInfoSchema() *schema.Resource {
 				Optional: true,
 			},
 			"decryption_key": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
 			},
 		},
 	}
@@ -170,6 +216,7 @@ func schemaToFirmwareComponentVersion(d *schema.ResourceData) mdm.FirmwareCompon
 }
 
 func firmwareComponentVersionToSchema(resource mdm.FirmwareComponentVersion, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("version", resource.Version)
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("firmware_component_id", resource.FirmwareComponentId.Reference)
@@ -202,14 +249,96 @@ func firmwareComponentVersionToSchema(resource mdm.FirmwareComponentVersion, d *
 	}
 }
 
+// resourceConnectMDMFirmwareComponentVersionCustomizeDiff plans a new upload when the source file or upload settings change
+func resourceConnectMDMFirmwareComponentVersionCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	source := d.Get("source").(string)
+	if source == "" {
+		return nil
+	}
+	hash, err := sourceFileHash(source)
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	if hash == d.Get("source_sha256").(string) && !d.HasChange("upload") && !d.HasChange("version") {
+		return nil
+	}
+	if err := d.SetNew("source_sha256", hash); err != nil {
+		return err
+	}
+	for _, k := range []string{"blob_url", "size", "fingerprint", "encryption_info"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uploadFirmwareSource uploads the source file when it changed since the last upload and
+// points blob_url, size, fingerprint and encryption_info at the uploaded artifact
+func uploadFirmwareSource(ctx context.Context, c *config.Config, principal *config.Principal, d *schema.ResourceData) error {
+	source := d.Get("source").(string)
+	if source == "" {
+		return nil
+	}
+	uploaded, _ := d.GetChange("source_sha256")
+	if d.Id() != "" && uploaded.(string) != "" && !d.HasChange("source_sha256") && !d.HasChange("upload") && !d.HasChange("version") {
+		return nil
+	}
+	settings := d.Get("upload").([]interface{})[0].(map[string]interface{})
+
+	artifact, err := prepareArtifact(source, settings["encrypt"].(bool))
+	if err != nil {
+		return err
+	}
+	client, err := c.BLRClient(principal)
+	if err != nil {
+		return err
+	}
+	objectPath := blobPath(settings["path_prefix"].(string), d.Get("version").(string), artifact.Name)
+	blob, err := uploadArtifact(ctx, client, settings["bucket"].(string), objectPath, artifact)
+	if err != nil {
+		return fmt.Errorf("uploading '%s': %w", source, err)
+	}
+
+	_ = d.Set("blob_url", blob.BlobURL)
+	_ = d.Set("size", artifact.Size)
+	_ = d.Set("source_sha256", artifact.SourceHash)
+	fingerprint := &schema.Set{F: schema.HashResource(fingerprintSchema())}
+	fingerprint.Add(map[string]interface{}{
+		"algorithm": fingerprintAlgorithm,
+		"hash":      artifact.Hash,
+	})
+	_ = d.Set("fingerprint", fingerprint)
+	encryptionInfo := &schema.Set{F: schema.HashResource(encryptionInfoSchema())}
+	if artifact.DecryptionKey != "" {
+		encryptionInfo.Add(map[string]interface{}{
+			"encrypted":      true,
+			"algorithm":      artifactEncryptionAlgorithm,
+			"decryption_key": artifact.DecryptionKey,
+		})
+	} else {
+		encryptionInfo.Add(map[string]interface{}{
+			"encrypted":      false,
+			"algorithm":      "",
+			"decryption_key": "",
+		})
+	}
+	_ = d.Set("encryption_info", encryptionInfo)
+	return nil
+}
+
 func resourceConnectMDMFirmwareComponentVersionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := uploadFirmwareSource(ctx, c, principal, d); err != nil {
+		return diag.FromErr(err)
+	}
 	resource := schemaToFirmwareComponentVersion(d)
 
 	var created *mdm.FirmwareComponentVersion
@@ -241,7 +370,8 @@ func resourceConnectMDMFirmwareComponentVersionRead(ctx context.Context, d *sche
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -277,19 +407,34 @@ func resourceConnectMDMFirmwareComponentVersionUpdate(ctx context.Context, d *sc
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.FirmwareComponentVersions.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMFirmwareComponentVersion(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		firmwareComponentVersionToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
+	if err := uploadFirmwareSource(ctx, c, principal, d); err != nil {
+		return diag.FromErr(err)
+	}
 	service := schemaToFirmwareComponentVersion(d)
 	service.ID = id
+	service.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.FirmwareComponentVersions.Update(service)
+	_, resp, err := client.FirmwareComponentVersions.Update(service)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -302,7 +447,8 @@ func resourceConnectMDMFirmwareComponentVersionDelete(_ context.Context, d *sche
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
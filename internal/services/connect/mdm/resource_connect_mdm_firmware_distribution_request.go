@@ -24,6 +24,7 @@ func ResourceConnectMDMFirmwareDistributionRequest() *schema.Resource {
 		DeprecationMessage: "This will be replace by the Firmware v2 API. Only use it for test/demo purposes!",
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"firmware_version": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -67,6 +68,10 @@ func ResourceConnectMDMFirmwareDistributionRequest() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -112,6 +117,7 @@ func schemaToFirmwareDistributionRequest(d *schema.ResourceData) mdm.FirmwareDis
 }
 
 func firmwareDistributionRequestToSchema(resource mdm.FirmwareDistributionRequest, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("firmware_version", resource.FirmwareVersion)
 	_ = d.Set("orchestration_mode", resource.OrchestrationMode)
@@ -135,7 +141,8 @@ func firmwareDistributionRequestToSchema(resource mdm.FirmwareDistributionReques
 func resourceConnectMDMFirmwareDistributionRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -172,7 +179,8 @@ func resourceConnectMDMFirmwareDistributionRequestRead(ctx context.Context, d *s
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -208,23 +216,37 @@ func resourceConnectMDMFirmwareDistributionRequestUpdate(ctx context.Context, d
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
-	resource := schemaToFirmwareDistributionRequest(d)
-	resource.ID = id
-
+	if !d.HasChangeExcept("force_update") {
+		return resourceConnectMDMFirmwareDistributionRequestRead(ctx, d, m)
+	}
 	if !d.HasChange("status") {
 		return diag.FromErr(fmt.Errorf("only the 'status' can be updated"))
 	}
+	current, _, err := client.FirmwareDistributionRequests.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMFirmwareDistributionRequest(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		firmwareDistributionRequestToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
+	resource := schemaToFirmwareDistributionRequest(d)
+	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.FirmwareDistributionRequests.Update(resource)
+	_, resp, err := client.FirmwareDistributionRequests.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -237,7 +259,8 @@ func resourceConnectMDMFirmwareDistributionRequestDelete(_ context.Context, d *s
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
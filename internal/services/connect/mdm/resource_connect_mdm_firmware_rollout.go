@@ -0,0 +1,562 @@
+package mdm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/philips-software/go-dip-api/connect/mdm"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+func ResourceConnectMDMFirmwareRollout() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConnectMDMFirmwareRolloutCreate,
+		ReadContext:   resourceConnectMDMFirmwareRolloutRead,
+		UpdateContext: resourceConnectMDMFirmwareRolloutUpdate,
+		DeleteContext: resourceConnectMDMFirmwareRolloutDelete,
+		CustomizeDiff: resourceConnectMDMFirmwareRolloutCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(24 * time.Hour),
+			Update: schema.DefaultTimeout(24 * time.Hour),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
+			"firmware_version": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+			},
+			"orchestration_mode": {
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"none", "continuous", "snapshot"}, false),
+			},
+			"user_consent_required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"firmware_component_version_ids": {
+				Type:     schema.TypeSet,
+				ForceNew: true,
+				Required: true,
+				MaxItems: 5,
+				Elem:     tools.StringSchema(),
+			},
+			"canary_device_group_ids": {
+				Type:     schema.TypeSet,
+				ForceNew: true,
+				Optional: true,
+				Elem:     tools.StringSchema(),
+			},
+			"device_group_ids": {
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem:     tools.StringSchema(),
+			},
+			"wave_percentages": {
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 100),
+				},
+			},
+			"success_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"wait_between_waves": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "0s",
+				ValidateFunc: validateDuration,
+			},
+			"wave_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "1h",
+				ValidateFunc: validateDuration,
+			},
+			"poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "30s",
+				ValidateFunc: validateDuration,
+			},
+			"on_failure": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "pause",
+				ValidateFunc: validation.StringInSlice([]string{"pause", "cancel"}, false),
+			},
+			"failed_wave_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      failedWaveRetry,
+				ValidateFunc: validation.StringInSlice([]string{failedWaveRetry, failedWaveSkip}, false),
+			},
+			"success_statuses": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     tools.StringSchema(),
+			},
+			"failure_statuses": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     tools.StringSchema(),
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"waves": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"index": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"device_group_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     tools.StringSchema(),
+						},
+						"request_ids": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     tools.StringSchema(),
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"succeeded": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"failed": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateDuration(i interface{}, k string) (warns []string, errs []error) {
+	duration, err := time.ParseDuration(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	if duration < 0 {
+		return nil, []error{fmt.Errorf("%s must not be negative", k)}
+	}
+	return nil, nil
+}
+
+// resourceConnectMDMFirmwareRolloutCustomizeDiff resumes a paused or interrupted rollout on the next apply
+func resourceConnectMDMFirmwareRolloutCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	switch d.Get("status").(string) {
+	case rolloutCompleted, rolloutCancelled:
+		return nil
+	}
+	if err := d.SetNewComputed("status"); err != nil {
+		return err
+	}
+	return d.SetNewComputed("waves")
+}
+
+type rolloutSettings struct {
+	successStatuses []string
+	failureStatuses []string
+	threshold       int
+	waitBetween     time.Duration
+	waveTimeout     time.Duration
+	pollInterval    time.Duration
+	cancelOnFailure bool
+	skipFailedWave  bool
+}
+
+func schemaToRolloutSettings(d *schema.ResourceData) rolloutSettings {
+	s := rolloutSettings{
+		successStatuses: tools.ExpandStringList(d.Get("success_statuses").(*schema.Set).List()),
+		failureStatuses: tools.ExpandStringList(d.Get("failure_statuses").(*schema.Set).List()),
+		threshold:       d.Get("success_threshold").(int),
+		cancelOnFailure: d.Get("on_failure").(string) == "cancel",
+		skipFailedWave:  d.Get("failed_wave_action").(string) == failedWaveSkip,
+	}
+	if len(s.successStatuses) == 0 {
+		s.successStatuses = defaultSuccessStatuses
+	}
+	if len(s.failureStatuses) == 0 {
+		s.failureStatuses = defaultFailureStatuses
+	}
+	// Durations are validated in the schema
+	s.waitBetween, _ = time.ParseDuration(d.Get("wait_between_waves").(string))
+	s.waveTimeout, _ = time.ParseDuration(d.Get("wave_timeout").(string))
+	s.pollInterval, _ = time.ParseDuration(d.Get("poll_interval").(string))
+	if s.pollInterval <= 0 {
+		s.pollInterval = 30 * time.Second
+	}
+	return s
+}
+
+func createWaveRequest(ctx context.Context, client *mdm.Client, d *schema.ResourceData, groupID string) (string, error) {
+	request := mdm.FirmwareDistributionRequest{
+		FirmwareVersion:     d.Get("firmware_version").(string),
+		Description:         d.Get("description").(string),
+		OrchestrationMode:   d.Get("orchestration_mode").(string),
+		UserConsentRequired: d.Get("user_consent_required").(bool),
+		Status:              distributionActive,
+		DistributionTargets: []mdm.Reference{{Reference: groupID}},
+	}
+	for _, v := range tools.ExpandStringList(d.Get("firmware_component_version_ids").(*schema.Set).List()) {
+		request.FirmwareComponentVersions = append(request.FirmwareComponentVersions, mdm.Reference{Reference: v})
+	}
+
+	var created *mdm.FirmwareDistributionRequest
+	var resp *mdm.Response
+	err := tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
+		var err error
+		created, resp, err = client.FirmwareDistributionRequests.Create(request)
+		if err != nil {
+			_ = client.TokenRefresh()
+		}
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating distribution request for device group '%s': %w", groupID, err)
+	}
+	if created == nil {
+		return "", fmt.Errorf("creating distribution request for device group '%s': %w", groupID, config.ErrInvalidResponse)
+	}
+	return created.ID, nil
+}
+
+func getDistributionRequest(ctx context.Context, client *mdm.Client, id string) (*mdm.FirmwareDistributionRequest, *mdm.Response, error) {
+	var resource *mdm.FirmwareDistributionRequest
+	var resp *mdm.Response
+	err := tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
+		var err error
+		resource, resp, err = client.FirmwareDistributionRequests.GetByID(id)
+		if err != nil {
+			_ = client.TokenRefresh()
+		}
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	return resource, resp, err
+}
+
+// waveStatuses returns the current status of every distribution request of the wave
+func waveStatuses(ctx context.Context, client *mdm.Client, wave rolloutWave) ([]string, error) {
+	var statuses []string
+	for _, g := range wave.DeviceGroupIDs {
+		id, ok := wave.RequestIDs[g]
+		if !ok {
+			statuses = append(statuses, "")
+			continue
+		}
+		request, resp, err := getDistributionRequest(ctx, client, id)
+		if err != nil {
+			if resp != nil && (resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusGone) {
+				statuses = append(statuses, distributionCanceled)
+				continue
+			}
+			return nil, fmt.Errorf("reading distribution request '%s': %w", id, err)
+		}
+		statuses = append(statuses, request.Status)
+	}
+	return statuses, nil
+}
+
+// cancelDistributionRequests cancels all requests that have not finished yet
+func cancelDistributionRequests(ctx context.Context, client *mdm.Client, ids []string, finished []string) error {
+	isFinished := statusSet(finished)
+	for _, id := range ids {
+		request, resp, err := getDistributionRequest(ctx, client, id)
+		if err != nil {
+			if resp != nil && (resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusGone) {
+				continue
+			}
+			return fmt.Errorf("reading distribution request '%s': %w", id, err)
+		}
+		if isFinished[strings.ToUpper(request.Status)] {
+			continue
+		}
+		request.Status = distributionCanceled
+		if _, _, err := client.FirmwareDistributionRequests.Update(*request); err != nil {
+			return fmt.Errorf("cancelling distribution request '%s': %w", id, err)
+		}
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// runFirmwareRollout drives the waves in order, starting at the first wave that did not succeed yet.
+// Progress is written to the state after every step so an interrupted rollout can be resumed
+func runFirmwareRollout(ctx context.Context, client *mdm.Client, d *schema.ResourceData) error {
+	settings := schemaToRolloutSettings(d)
+	waves := expandRolloutWaves(d.Get("waves").([]interface{}))
+	save := func(status string) {
+		_ = d.Set("waves", flattenRolloutWaves(waves))
+		_ = d.Set("status", status)
+	}
+	// pause saves the rollout as paused when it is interrupted, e.g. by a timeout or a failing API call
+	pause := func(err error) error {
+		save(rolloutPaused)
+		return fmt.Errorf("%w: %w. Apply again to resume", errRolloutPaused, err)
+	}
+
+	for i := range waves {
+		wave := &waves[i]
+		if wave.Status == waveFailed {
+			statuses, err := waveStatuses(ctx, client, *wave)
+			if err != nil {
+				return pause(err)
+			}
+			resumeFailedWave(wave, statuses, settings.failureStatuses, settings.skipFailedWave)
+		}
+		if wave.Status == waveSucceeded || wave.Status == waveSkipped {
+			continue
+		}
+		if i > 0 && wave.Status == wavePending {
+			if err := sleepContext(ctx, settings.waitBetween); err != nil {
+				return pause(fmt.Errorf("waiting before wave %d: %w", i, err))
+			}
+		}
+		for _, g := range wave.DeviceGroupIDs {
+			if _, ok := wave.RequestIDs[g]; ok {
+				continue
+			}
+			id, err := createWaveRequest(ctx, client, d, g)
+			if err != nil {
+				return pause(err)
+			}
+			wave.RequestIDs[g] = id
+		}
+		wave.Status = waveInProgress
+		save(rolloutInProgress)
+
+		deadline := time.Now().Add(settings.waveTimeout)
+		for {
+			statuses, err := waveStatuses(ctx, client, *wave)
+			if err != nil {
+				return pause(err)
+			}
+			var outcome string
+			wave.Succeeded, wave.Failed, outcome = evaluateWave(statuses, settings.successStatuses, settings.failureStatuses, settings.threshold)
+			if outcome == waveSucceeded {
+				wave.Status = waveSucceeded
+				save(rolloutInProgress)
+				break
+			}
+			if outcome == waveInProgress && time.Now().Before(deadline) {
+				if err := sleepContext(ctx, settings.pollInterval); err != nil {
+					return pause(fmt.Errorf("waiting for wave %d: %w", i, err))
+				}
+				continue
+			}
+			reason := fmt.Sprintf("%d of %d device groups failed", wave.Failed, len(wave.DeviceGroupIDs))
+			if outcome == waveInProgress {
+				reason = fmt.Sprintf("timeout after %s with %d of %d device groups succeeded", settings.waveTimeout, wave.Succeeded, len(wave.DeviceGroupIDs))
+			}
+			if settings.cancelOnFailure {
+				cancelErr := cancelDistributionRequests(ctx, client, requestIDs(waves), append(settings.successStatuses, settings.failureStatuses...))
+				for j := i; j < len(waves); j++ {
+					waves[j].Status = waveCancelled
+				}
+				save(rolloutCancelled)
+				if cancelErr != nil {
+					return fmt.Errorf("%w: wave %d did not reach %d%% success (%s), cancelling failed: %w", errRolloutCancelled, i, settings.threshold, reason, cancelErr)
+				}
+				return fmt.Errorf("%w: wave %d did not reach %d%% success (%s)", errRolloutCancelled, i, settings.threshold, reason)
+			}
+			wave.Status = waveFailed
+			save(rolloutPaused)
+			return fmt.Errorf("%w: wave %d did not reach %d%% success (%s). Apply again to resume", errRolloutPaused, i, settings.threshold, reason)
+		}
+	}
+	save(rolloutCompleted)
+	return nil
+}
+
+// rolloutDiags reports a rollout that was paused or cancelled as a warning, so a started rollout is
+// kept in the state instead of being tainted. Other errors are returned as is
+func rolloutDiags(err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err == nil {
+		return diags
+	}
+	if !errors.Is(err, errRolloutPaused) && !errors.Is(err, errRolloutCancelled) {
+		return diag.FromErr(err)
+	}
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "firmware rollout did not complete",
+		Detail:   err.Error(),
+	})
+}
+
+func resourceConnectMDMFirmwareRolloutCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	waves, err := planWaves(
+		tools.ExpandStringList(d.Get("canary_device_group_ids").(*schema.Set).List()),
+		tools.ExpandStringList(d.Get("device_group_ids").([]interface{})),
+		expandIntList(d.Get("wave_percentages").([]interface{})))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(uuid.NewString())
+	_ = d.Set("waves", flattenRolloutWaves(waves))
+	_ = d.Set("status", rolloutInProgress)
+
+	runCtx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	if err := runFirmwareRollout(runCtx, client, d); err != nil {
+		// The progress is already saved, refreshing could fail for the same reason the rollout stopped
+		return rolloutDiags(err)
+	}
+	return resourceConnectMDMFirmwareRolloutRead(ctx, d, m)
+}
+
+func expandIntList(raw []interface{}) []int {
+	var result []int
+	for _, v := range raw {
+		result = append(result, v.(int))
+	}
+	return result
+}
+
+func resourceConnectMDMFirmwareRolloutRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	settings := schemaToRolloutSettings(d)
+	waves := expandRolloutWaves(d.Get("waves").([]interface{}))
+	for i := range waves {
+		if len(waves[i].RequestIDs) == 0 {
+			continue
+		}
+		statuses, err := waveStatuses(ctx, client, waves[i])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		waves[i].Succeeded, waves[i].Failed, _ = evaluateWave(statuses, settings.successStatuses, settings.failureStatuses, settings.threshold)
+	}
+	_ = d.Set("waves", flattenRolloutWaves(waves))
+	return diags
+}
+
+func resourceConnectMDMFirmwareRolloutUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// waves and status are unknown in the plan while resuming, continue from the state
+	previousWaves, _ := d.GetChange("waves")
+	previousStatus, _ := d.GetChange("status")
+	_ = d.Set("waves", previousWaves)
+	_ = d.Set("status", previousStatus)
+	switch previousStatus.(string) {
+	case rolloutCompleted, rolloutCancelled:
+		return resourceConnectMDMFirmwareRolloutRead(ctx, d, m)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	if err := runFirmwareRollout(runCtx, client, d); err != nil {
+		// The progress is already saved, refreshing could fail for the same reason the rollout stopped
+		return rolloutDiags(err)
+	}
+	return resourceConnectMDMFirmwareRolloutRead(ctx, d, m)
+}
+
+func resourceConnectMDMFirmwareRolloutDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	settings := schemaToRolloutSettings(d)
+	waves := expandRolloutWaves(d.Get("waves").([]interface{}))
+	if err := cancelDistributionRequests(ctx, client, requestIDs(waves), append(settings.successStatuses, settings.failureStatuses...)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return diags
+}
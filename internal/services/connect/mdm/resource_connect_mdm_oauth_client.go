@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,8 +25,10 @@ func ResourceConnectMDMOAuthClient() *schema.Resource {
 		ReadContext:   resourceConnectMDMOAuthClientRead,
 		UpdateContext: resourceConnectMDMOAuthClientUpdate,
 		DeleteContext: resourceConnectMDMOAuthClientDelete,
+		CustomizeDiff: customizeOAuthClientDiff,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -164,10 +167,29 @@ func ResourceConnectMDMOAuthClient() *schema.Resource {
 				Computed:  true,
 				Sensitive: true,
 			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value which, when changed, regenerates the client and bootstrap client secrets in-place.",
+			},
+			"secret_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the client secrets, incremented on every rotation.",
+			},
+			"rotated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the client secrets were last set.",
+			},
 			"version_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -212,6 +234,7 @@ func setScopes(client *mdm.Client, iamClient *iam.Client, d *schema.ResourceData
 		}
 	}
 
+	resource.Meta = lastReadMeta(d, resource.Meta)
 	_, _, err = client.OAuthClients.UpdateScopes(*resource, scopes, defaultScopes)
 	if err != nil {
 		return fmt.Errorf("updating scopes: %w", err)
@@ -302,6 +325,7 @@ func schemaToOAuthClient(d *schema.ResourceData) mdm.OAuthClient {
 }
 
 func oAuthClientToSchema(resource mdm.OAuthClient, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("application_id", resource.ApplicationId.Reference)
@@ -332,11 +356,12 @@ func oAuthClientToSchema(resource mdm.OAuthClient, d *schema.ResourceData) {
 func resourceConnectMDMOAuthClientCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	iamClient, err := c.IAMClient()
+	iamClient, err := c.IAMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -377,6 +402,8 @@ func resourceConnectMDMOAuthClientCreate(ctx context.Context, d *schema.Resource
 	_ = d.Set("guid", created.ID)
 	_ = d.Set("bootstrap_client_secret", created.BootstrapClientSecret)
 	_ = d.Set("client_secret", created.ClientSecret)
+	_ = d.Set("secret_version", 1)
+	_ = d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
 
 	if err := setScopes(client, iamClient, d); err != nil {
 		// Clean up
@@ -400,11 +427,12 @@ func resourceConnectMDMOAuthClientRead(ctx context.Context, d *schema.ResourceDa
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	iamClient, err := c.IAMClient()
+	iamClient, err := c.IAMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -441,33 +469,123 @@ func resourceConnectMDMOAuthClientRead(ctx context.Context, d *schema.ResourceDa
 func resourceConnectMDMOAuthClientUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	iamClient, err := c.IAMClient()
+	iamClient, err := c.IAMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if !d.HasChange("scopes") && !d.HasChange("default_scopes") && !d.HasChange("iam_scopes") && !d.HasChange("iam_default_scopes") &&
-		!d.HasChange("bootstrap_client_scopes") && !d.HasChange("bootstrap_client_default_scopes") &&
-		!d.HasChange("bootstrap_client_iam_scopes") && !d.HasChange("bootstrap_client_iam_default_scopes") {
+	if !d.HasChangeExcept("force_update") {
+		return resourceConnectMDMOAuthClientRead(ctx, d, m)
+	}
+	scopesChanged := d.HasChanges("scopes", "default_scopes", "iam_scopes", "iam_default_scopes",
+		"bootstrap_client_scopes", "bootstrap_client_default_scopes",
+		"bootstrap_client_iam_scopes", "bootstrap_client_iam_default_scopes")
+	if !scopesChanged && !d.HasChange("rotation_trigger") {
 		return diag.FromErr(fmt.Errorf("only 'scopes', 'default_scopes', 'iam_scopes', 'iam_default_scopes', " +
-			"'bootstrap_client_scopes', 'bootstrap_client_default_scopes', 'bootstrap_client_iam_scopes' " +
-			"or 'bootstrap_client_iam_default_scopes' can be updated. this is a bug"))
+			"'bootstrap_client_scopes', 'bootstrap_client_default_scopes', 'bootstrap_client_iam_scopes', " +
+			"'bootstrap_client_iam_default_scopes' or 'rotation_trigger' can be updated. this is a bug"))
 	}
-	if err := setScopes(client, iamClient, d); err != nil {
+	id := d.Get("guid").(string)
+	current, _, err := client.OAuthClients.GetOAuthClientByID(id)
+	if err != nil {
 		return diag.FromErr(err)
 	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMOAuthClient(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		oAuthClientToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+	if scopesChanged {
+		if err := setScopes(client, iamClient, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChange("rotation_trigger") {
+		if err := rotateOAuthClientSecrets(iamClient, *current, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceConnectMDMOAuthClientRead(ctx, d, m)
 }
 
+// customizeOAuthClientDiff marks the secrets and their metadata as unknown
+// when rotation_trigger changes so the plan shows the rotation.
+func customizeOAuthClientDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("rotation_trigger") {
+		return nil
+	}
+	for _, key := range []string{"client_secret", "bootstrap_client_secret", "secret_version", "rotated_at"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotateOAuthClientSecrets regenerates the secrets of the IAM clients backing the MDM OAuth client. The clients
+// are updated in-place so client_id and the client GUIDs stay the same
+func rotateOAuthClientSecrets(iamClient *iam.Client, resource mdm.OAuthClient, d *schema.ResourceData) error {
+	if resource.ClientGuid == nil || resource.ClientGuid.Value == "" {
+		return fmt.Errorf("rotating secrets: missing IAM client GUID")
+	}
+	// The bootstrap client goes first so a failure leaves both secrets untouched
+	bootstrapClientSecret, _ := d.GetChange("bootstrap_client_secret")
+	if resource.BootstrapClientGuid != nil && resource.BootstrapClientGuid.Value != "" {
+		secret, err := rotateIAMClientSecret(iamClient, resource.BootstrapClientGuid.Value)
+		if err != nil {
+			d.Partial(true)
+			return fmt.Errorf("rotating bootstrap client secret: %w", err)
+		}
+		bootstrapClientSecret = secret
+	}
+	_ = d.Set("bootstrap_client_secret", bootstrapClientSecret)
+
+	secretVersion, _ := d.GetChange("secret_version")
+	clientSecret, err := rotateIAMClientSecret(iamClient, resource.ClientGuid.Value)
+	if err != nil {
+		// The bootstrap client secret was already rotated, so keep it and
+		// the previous trigger so the next apply retries the client secret
+		previousClientSecret, _ := d.GetChange("client_secret")
+		previousTrigger, _ := d.GetChange("rotation_trigger")
+		_ = d.Set("client_secret", previousClientSecret)
+		_ = d.Set("rotation_trigger", previousTrigger)
+		_ = d.Set("secret_version", secretVersion.(int)+1)
+		_ = d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+		return fmt.Errorf("rotating client secret: %w", err)
+	}
+	_ = d.Set("client_secret", clientSecret)
+	_ = d.Set("secret_version", secretVersion.(int)+1)
+	_ = d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+	return nil
+}
+
+func rotateIAMClientSecret(iamClient *iam.Client, guid string) (string, error) {
+	password, err := tools.RandomPassword()
+	if err != nil {
+		return "", err
+	}
+	current, _, err := iamClient.Clients.GetClientByID(guid)
+	if err != nil {
+		return "", err
+	}
+	current.Password = password
+	if _, _, err := iamClient.Clients.UpdateClient(*current); err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
 func resourceConnectMDMOAuthClientDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -28,6 +28,7 @@ func ResourceMDMProposition() *schema.Resource {
 		DeleteContext: resourceMDMPropositionDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -128,7 +129,8 @@ func resourceMDMPropositionCreate(ctx context.Context, d *schema.ResourceData, m
 
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -191,7 +193,8 @@ func resourceMDMPropositionRead(ctx context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 
 	c := m.(*config.Config)
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -236,7 +239,8 @@ func resourceMDMPropositionUpdate(_ context.Context, d *schema.ResourceData, m i
 	var diags diag.Diagnostics
 
 	c := m.(*config.Config)
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -25,6 +25,7 @@ func ResourceConnectMDMServiceAction() *schema.Resource {
 		DeleteContext: resourceConnectMDMServiceActionDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -48,6 +49,10 @@ func ResourceConnectMDMServiceAction() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -82,6 +87,7 @@ func schemaToServiceAction(d *schema.ResourceData) mdm.ServiceAction {
 }
 
 func serviceActionToSchema(service mdm.ServiceAction, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(service.Meta))
 	_ = d.Set("description", service.Description)
 	_ = d.Set("name", service.Name)
 	_ = d.Set("standard_service_id", service.StandardServiceId.Reference)
@@ -100,7 +106,8 @@ func serviceActionToSchema(service mdm.ServiceAction, d *schema.ResourceData) {
 func resourceConnectMDMServiceActionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -136,7 +143,8 @@ func resourceConnectMDMServiceActionRead(ctx context.Context, d *schema.Resource
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -172,19 +180,31 @@ func resourceConnectMDMServiceActionUpdate(ctx context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.ServiceActions.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMServiceAction(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		serviceActionToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	service := schemaToServiceAction(d)
 	service.ID = id
+	service.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.ServiceActions.Update(service)
+	_, resp, err := client.ServiceActions.Update(service)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -197,7 +217,8 @@ func resourceConnectMDMServiceActionDelete(_ context.Context, d *schema.Resource
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -23,6 +23,7 @@ func ResourceConnectMDMServiceReference() *schema.Resource {
 		DeleteContext: resourceConnectMDMServiceReferenceDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -61,6 +62,10 @@ func ResourceConnectMDMServiceReference() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -93,6 +98,7 @@ func schemaToServiceReference(d *schema.ResourceData) mdm.ServiceReference {
 }
 
 func ServiceReferenceToSchema(resource mdm.ServiceReference, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(resource.Meta))
 	_ = d.Set("description", resource.Description)
 	_ = d.Set("name", resource.Name)
 	_ = d.Set("application_id", resource.ApplicationID.Reference)
@@ -111,7 +117,8 @@ func ServiceReferenceToSchema(resource mdm.ServiceReference, d *schema.ResourceD
 func resourceConnectMDMServiceReferenceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -147,7 +154,8 @@ func resourceConnectMDMServiceReferenceRead(ctx context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -183,19 +191,31 @@ func resourceConnectMDMServiceReferenceUpdate(ctx context.Context, d *schema.Res
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.ServiceReferences.GetByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMServiceReference(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		ServiceReferenceToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	resource := schemaToServiceReference(d)
 	resource.ID = id
+	resource.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.ServiceReferences.Update(resource)
+	_, resp, err := client.ServiceReferences.Update(resource)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -208,7 +228,8 @@ func resourceConnectMDMServiceReferenceDelete(_ context.Context, d *schema.Resou
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
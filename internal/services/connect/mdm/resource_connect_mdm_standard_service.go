@@ -25,6 +25,7 @@ func ResourceConnectMDMStandardService() *schema.Resource {
 		DeleteContext: resourceConnectMDMStandardServiceDelete,
 
 		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
 			"name": {
 				Type:     schema.TypeString,
 				ForceNew: true,
@@ -63,6 +64,10 @@ func ResourceConnectMDMStandardService() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"force_update": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 			"guid": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -139,6 +144,7 @@ func schemaToStandardService(d *schema.ResourceData) mdm.StandardService {
 }
 
 func standardServiceToSchema(service mdm.StandardService, d *schema.ResourceData) {
+	_ = d.Set("version_id", metaVersionID(service.Meta))
 	s := &schema.Set{F: schema.HashResource(serviceURLSchema())}
 	for _, serviceURL := range service.ServiceUrls {
 		entry := make(map[string]interface{})
@@ -168,7 +174,8 @@ func standardServiceToSchema(service mdm.StandardService, d *schema.ResourceData
 func resourceConnectMDMStandardServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -204,7 +211,8 @@ func resourceConnectMDMStandardServiceRead(ctx context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -240,19 +248,31 @@ func resourceConnectMDMStandardServiceUpdate(ctx context.Context, d *schema.Reso
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	id := d.Get("guid").(string)
 
+	current, _, err := client.StandardServices.GetStandardServiceByID(id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if conflict := checkVersionConflict(d, ResourceConnectMDMStandardService(), metaVersionID(current.Meta), func(remote *schema.ResourceData) {
+		standardServiceToSchema(*current, remote)
+	}); conflict != nil {
+		return conflict
+	}
+
 	service := schemaToStandardService(d)
 	service.ID = id
+	service.Meta = lastReadMeta(d, current.Meta)
 
-	_, _, err = client.StandardServices.Update(service)
+	_, resp, err := client.StandardServices.Update(service)
 	if err != nil {
-		diags = append(diags, diag.FromErr(err)...)
+		diags = append(diags, updateErrorDiags(d, resp, err)...)
 	}
 	if len(diags) > 0 {
 		return diags
@@ -265,7 +285,8 @@ func resourceConnectMDMStandardServiceDelete(_ context.Context, d *schema.Resour
 
 	var diags diag.Diagnostics
 
-	client, err := c.MDMClient()
+	principal := config.SchemaToPrincipal(d, m)
+	client, err := c.MDMClient(principal)
 	if err != nil {
 		return diag.FromErr(err)
 	}
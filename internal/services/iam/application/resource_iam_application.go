@@ -13,13 +13,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/philips-software/go-dip-api/iam"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/importer"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 )
 
 func ResourceIAMApplication() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateContext("application"),
 		},
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -2,13 +2,17 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/importer"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/philips-software/go-dip-api/iam"
 )
 
@@ -20,7 +24,7 @@ func ResourceIAMClient() *schema.Resource {
 	return &schema.Resource{
 		SchemaVersion: 1,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateContext("client"),
 		},
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -33,6 +37,7 @@ func ResourceIAMClient() *schema.Resource {
 		ReadContext:   resourceIAMClientRead,
 		UpdateContext: resourceIAMClientUpdate,
 		DeleteContext: resourceIAMClientDelete,
+		CustomizeDiff: customizeClientDiff,
 		Description:   descriptions["client"],
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -56,10 +61,33 @@ func ResourceIAMClient() *schema.Resource {
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Sensitive:   true,
-				ForceNew:    true,
-				Description: "The password to use (8-16 chars, at least one capital, number, special char).",
+				Description: "The password to use (8-16 chars, at least one capital, number, special char). When not set, a password is generated. Changing it updates the secret in-place.",
+			},
+			"rotation_trigger": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"password"},
+				Description:   "Arbitrary value which, when changed, rotates the generated client secret in-place.",
+			},
+			"rotation_days": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"password"},
+				ValidateFunc:  validation.IntAtLeast(1),
+				Description:   "Rotate the generated client secret in-place when it is older than this number of days.",
+			},
+			"secret_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version of the client secret, incremented on every secret change.",
+			},
+			"rotated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the client secret was last set.",
 			},
 			"description": {
 				Type:        schema.TypeString,
@@ -161,6 +189,12 @@ func resourceIAMClientCreate(ctx context.Context, d *schema.ResourceData, m inte
 	cl.Type = d.Get("type").(string)
 	cl.GlobalReferenceID = d.Get("global_reference_id").(string)
 	cl.Password = d.Get("password").(string)
+	if cl.Password == "" {
+		cl.Password, err = tools.RandomPassword()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	cl.Name = d.Get("name").(string)
 	cl.RedirectionURIs = tools.ExpandStringList(d.Get("redirection_uris").(*schema.Set).List())
 	cl.ResponseTypes = tools.ExpandStringList(d.Get("response_types").(*schema.Set).List())
@@ -191,6 +225,8 @@ func resourceIAMClientCreate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 	d.SetId(createdClient.ID)
 	_ = d.Set("password", cl.Password)
+	_ = d.Set("secret_version", 1)
+	_ = d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
 	return resourceIAMClientRead(ctx, d, m)
 }
 
@@ -260,6 +296,28 @@ func resourceIAMClientUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	var cl iam.ApplicationClient
 	cl.ID = d.Id()
 
+	if d.HasChange("password") || d.HasChange("rotation_trigger") {
+		password := d.Get("password").(string)
+		if password == "" || !d.HasChange("password") { // Rotation of a generated secret
+			password, err = tools.RandomPassword()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		current, _, err := client.Clients.GetClientByID(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		current.Password = password
+		_, _, err = client.Clients.UpdateClient(*current)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("rotating client secret: %w", err))
+		}
+		secretVersion, _ := d.GetChange("secret_version")
+		_ = d.Set("password", password)
+		_ = d.Set("secret_version", secretVersion.(int)+1)
+		_ = d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+	}
 	if d.HasChange("scopes") || d.HasChange("default_scopes") {
 		newScopes := tools.ExpandStringList(d.Get("scopes").(*schema.Set).List())
 		newDefaultScopes := tools.ExpandStringList(d.Get("default_scopes").(*schema.Set).List())
@@ -305,6 +363,52 @@ func resourceIAMClientUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	return diags
 }
 
+// customizeClientDiff plans a secret rotation when rotation_trigger changes or once the generated secret is
+// older than rotation_days
+func customizeClientDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("password") {
+		// The configured secret is known, only its metadata changes
+		for _, key := range []string{"secret_version", "rotated_at"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !d.HasChange("rotation_trigger") {
+		days := d.Get("rotation_days").(int)
+		if days == 0 {
+			return nil
+		}
+		due, err := secretRotationDue(d.Get("rotated_at").(string), days, time.Now())
+		if err != nil || !due {
+			return err
+		}
+	}
+	for _, key := range []string{"password", "secret_version", "rotated_at"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secretRotationDue returns true when rotatedAt is at least days in the past.
+// Secrets without a known rotation time are considered due.
+func secretRotationDue(rotatedAt string, days int, now time.Time) (bool, error) {
+	if rotatedAt == "" {
+		return true, nil
+	}
+	rotated, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false, fmt.Errorf("parsing rotated_at '%s': %w", rotatedAt, err)
+	}
+	return !now.Before(rotated.AddDate(0, 0, days)), nil
+}
+
 func resourceIAMClientDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
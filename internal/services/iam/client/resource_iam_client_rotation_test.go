@@ -0,0 +1,27 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretRotationDue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	due, err := secretRotationDue("2025-05-01T12:00:00Z", 90, now)
+	assert.Nil(t, err)
+	assert.False(t, due)
+
+	due, err = secretRotationDue("2025-03-01T12:00:00Z", 90, now)
+	assert.Nil(t, err)
+	assert.True(t, due)
+
+	due, err = secretRotationDue("", 90, now)
+	assert.Nil(t, err)
+	assert.True(t, due, "secrets of unknown age should be rotated")
+
+	_, err = secretRotationDue("yesterday", 90, now)
+	assert.NotNil(t, err)
+}
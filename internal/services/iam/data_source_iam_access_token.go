@@ -0,0 +1,156 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+func DataSourceIAMAccessToken() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIAMAccessTokenRead,
+		Schema: map[string]*schema.Schema{
+			"principal": config.PrincipalSchema(),
+			"scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"exchange": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"requested_subject": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"audience": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"scopes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"requested_token_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  tokenTypeAccessToken,
+						},
+					},
+				},
+			},
+			"access_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"id_token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"token_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expires_at": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"subject": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"granted_scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceIAMAccessTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	principal := config.SchemaToPrincipal(d, m)
+	if !principal.HasAuth() {
+		return diag.FromErr(fmt.Errorf("principal must contain either username/password or service_id/service_private_key"))
+	}
+	principal.Scopes = tools.ExpandStringList(d.Get("scopes").(*schema.Set).List())
+
+	client, err := c.IAMClient(principal)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("login as principal: %w", err))
+	}
+	if !client.HasOAuth2Credentials() {
+		return diag.FromErr(fmt.Errorf("missing OAuth2 credentials, add 'oauth2_client_id' and 'oauth2_password' to the principal or provider"))
+	}
+	token, err := client.Token()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	introspect, _, err := client.Introspect()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("introspecting principal token: %w", err))
+	}
+
+	accessToken := token
+	idToken := client.IDToken()
+	tokenType := "Bearer"
+	expires := client.Expires()
+	subject := introspect.Sub
+	granted := splitScopes(introspect.Scope)
+
+	if v, ok := d.GetOk("exchange"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		ex := v.([]interface{})[0].(map[string]interface{})
+		clientID, clientSecret := c.OAuth2ClientID, c.OAuth2ClientSecret
+		if principal.OAuth2ClientID != "" {
+			clientID, clientSecret = principal.OAuth2ClientID, principal.OAuth2Password
+		}
+		exchanger := newTokenExchanger(client.BaseIAMURL(), clientID, clientSecret)
+		result, err := exchanger.exchange(ctx, tokenExchangeRequest{
+			SubjectToken:       token,
+			RequestedSubject:   ex["requested_subject"].(string),
+			Audience:           ex["audience"].(string),
+			Scopes:             tools.ExpandStringList(ex["scopes"].(*schema.Set).List()),
+			RequestedTokenType: ex["requested_token_type"].(string),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		accessToken = result.AccessToken
+		idToken = result.IDToken
+		if result.TokenType != "" {
+			tokenType = result.TokenType
+		}
+		expires = expiresAt(time.Now(), result.ExpiresIn)
+		if requested := ex["requested_subject"].(string); requested != "" {
+			subject = requested
+		}
+		if result.Scope != "" {
+			granted = splitScopes(result.Scope)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("token-%s-%s", client.BaseIAMURL().Host, subject))
+	_ = d.Set("access_token", accessToken)
+	_ = d.Set("id_token", idToken)
+	_ = d.Set("token_type", tokenType)
+	_ = d.Set("expires_at", expires)
+	_ = d.Set("subject", subject)
+	_ = d.Set("granted_scopes", granted)
+
+	return diags
+}
@@ -0,0 +1,159 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceIAMMFAPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIAMMFAPolicyRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"organization_id", "user_id"},
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"effective": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"found": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"policy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"inherited": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// firstMFAPolicy returns the first MFA policy attached to the given user or organization
+func firstMFAPolicy(client *iam.Client, opts *iam.GetMFAPolicyOptions) (*iam.MFAPolicy, error) {
+	policies, _, err := client.MFAPolicies.GetMFAPolicies(opts)
+	if err != nil {
+		return nil, err
+	}
+	if policies == nil || len(*policies) == 0 {
+		return nil, nil
+	}
+	return &(*policies)[0], nil
+}
+
+func dataSourceIAMMFAPolicyRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userID, orgID, err := policySubject(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	effective := d.Get("effective").(bool)
+
+	var found *iam.MFAPolicy
+	sourceType := ""
+	sourceID := ""
+	if userID != "" {
+		found, err = firstMFAPolicy(client, &iam.GetMFAPolicyOptions{UserID: &userID})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("reading MFA policy of user '%s': %w", userID, err))
+		}
+		if found != nil {
+			sourceType, sourceID = "User", userID
+		}
+	}
+	if found == nil && (userID == "" || effective) {
+		visit := func(id string) (bool, error) {
+			policy, err := firstMFAPolicy(client, &iam.GetMFAPolicyOptions{OrganizationID: &id})
+			if err != nil {
+				return false, fmt.Errorf("reading MFA policy of organization '%s': %w", id, err)
+			}
+			found = policy
+			return policy != nil, nil
+		}
+		parentOf := func(string) (string, error) { return "", nil }
+		if effective {
+			parentOf = orgParentFunc(client)
+		}
+		sourceOrgID, err := walkOrgChain(orgID, parentOf, visit)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if found != nil {
+			sourceType, sourceID = "Organization", sourceOrgID
+		}
+	}
+
+	_ = d.Set("found", found != nil)
+	_ = d.Set("source_type", sourceType)
+	_ = d.Set("source_id", sourceID)
+	subjectID := orgID
+	if userID != "" {
+		subjectID = userID
+	}
+	_ = d.Set("inherited", found != nil && sourceID != subjectID)
+	if found != nil {
+		_ = d.Set("policy_id", found.ID)
+		_ = d.Set("name", found.Name)
+		_ = d.Set("description", found.Description)
+		if len(found.Types) > 0 {
+			_ = d.Set("type", found.Types[0])
+		}
+		if found.Active != nil {
+			_ = d.Set("active", *found.Active)
+		}
+		_ = d.Set("version", found.Meta.Version)
+	}
+	d.SetId(subjectID)
+	return diags
+}
@@ -0,0 +1,199 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceIAMPasswordPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIAMPasswordPolicyRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"organization_id", "user_id"},
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"effective": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"found": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"policy_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"source_organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"inherited": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"expiry_period_in_days": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"history_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"complexity": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"max_length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_numerics": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_uppercase": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_lowercase": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_special_chars": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"challenges_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"challenge_policy": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"default_questions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"min_question_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"min_answer_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"max_incorrect_attempts": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func passwordPolicyToResourceData(policy iam.PasswordPolicy, d *schema.ResourceData) {
+	_ = d.Set("policy_id", policy.ID)
+	_ = d.Set("expiry_period_in_days", policy.ExpiryPeriodInDays)
+	_ = d.Set("history_count", policy.HistoryCount)
+	_ = d.Set("complexity", []interface{}{
+		map[string]interface{}{
+			"min_length":        policy.Complexity.MinLength,
+			"max_length":        policy.Complexity.MaxLength,
+			"min_numerics":      policy.Complexity.MinNumerics,
+			"min_uppercase":     policy.Complexity.MinUpperCase,
+			"min_lowercase":     policy.Complexity.MinLowerCase,
+			"min_special_chars": policy.Complexity.MinSpecialChars,
+		},
+	})
+	_ = d.Set("challenges_enabled", policy.ChallengesEnabled)
+	var challengePolicy []interface{}
+	if policy.ChallengePolicy != nil {
+		challengePolicy = append(challengePolicy, map[string]interface{}{
+			"default_questions":      policy.ChallengePolicy.DefaultQuestions,
+			"min_question_count":     policy.ChallengePolicy.MinQuestionCount,
+			"min_answer_count":       policy.ChallengePolicy.MinAnswerCount,
+			"max_incorrect_attempts": policy.ChallengePolicy.MaxIncorrectAttempts,
+		})
+	}
+	_ = d.Set("challenge_policy", challengePolicy)
+}
+
+func dataSourceIAMPasswordPolicyRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	userID, orgID, err := policySubject(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Users do not have a password policy of their own, so always resolve
+	effective := d.Get("effective").(bool) || userID != ""
+
+	var found *iam.PasswordPolicy
+	visit := func(id string) (bool, error) {
+		policies, _, err := client.PasswordPolicies.GetPasswordPolicies(&iam.GetPasswordPolicyOptions{
+			OrganizationID: &id,
+		})
+		if err != nil {
+			return false, fmt.Errorf("reading password policy of organization '%s': %w", id, err)
+		}
+		if policies == nil || len(*policies) == 0 {
+			return false, nil
+		}
+		found = &(*policies)[0]
+		return true, nil
+	}
+	parentOf := func(string) (string, error) { return "", nil }
+	if effective {
+		parentOf = orgParentFunc(client)
+	}
+	sourceOrgID, err := walkOrgChain(orgID, parentOf, visit)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("found", found != nil)
+	_ = d.Set("source_organization_id", sourceOrgID)
+	_ = d.Set("inherited", found != nil && sourceOrgID != orgID)
+	if found != nil {
+		passwordPolicyToResourceData(*found, d)
+	}
+	if userID != "" {
+		d.SetId(userID)
+	} else {
+		d.SetId(orgID)
+	}
+	return diags
+}
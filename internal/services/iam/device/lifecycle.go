@@ -0,0 +1,117 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+// debugUntil returns the end of a debug window of the given duration starting at now
+func debugUntil(now time.Time, debugFor string) (string, error) {
+	duration, err := time.ParseDuration(debugFor)
+	if err != nil {
+		return "", fmt.Errorf("invalid debug_for '%s': %w", debugFor, err)
+	}
+	return now.Add(duration).UTC().Format(time.RFC3339), nil
+}
+
+func validateDebugFor(i interface{}, k string) (warns []string, errs []error) {
+	duration, err := time.ParseDuration(i.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	if duration <= 0 {
+		return nil, []error{fmt.Errorf("%s must be a positive duration", k)}
+	}
+	return nil, nil
+}
+
+// applyDebugFor sets debug_until when a new debug window is requested
+func applyDebugFor(d *schema.ResourceData) error {
+	debugFor := d.Get("debug_for").(string)
+	if debugFor == "" || (d.Id() != "" && !d.HasChange("debug_for")) {
+		return nil
+	}
+	until, err := debugUntil(time.Now(), debugFor)
+	if err != nil {
+		return err
+	}
+	return d.Set("debug_until", until)
+}
+
+// customizeDeviceDiff plans debug_until as unknown when debug_for starts a new debug window, as the end
+// time is only known at apply
+func customizeDeviceDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.NewValueKnown("debug_for") && d.Get("debug_for").(string) == "" {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("debug_for") {
+		return nil
+	}
+	return d.SetNewComputed("debug_until")
+}
+
+// isActiveSetting returns the configured is_active value. Devices are active by default
+func isActiveSetting(d *schema.ResourceData) bool {
+	if d.GetRawConfig().GetAttr("is_active").IsNull() && d.Id() == "" {
+		return true
+	}
+	return d.Get("is_active").(bool)
+}
+
+// deviceGroupIDs returns the IDs of the groups the device is a member of
+func deviceGroupIDs(client *iam.Client, deviceID string) ([]string, *iam.Response, error) {
+	groups, resp, err := client.Groups.GetGroups(&iam.GetGroupOptions{
+		MemberType: tools.String("DEVICE"),
+		MemberID:   &deviceID,
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	var ids []string
+	if groups != nil {
+		for _, g := range *groups {
+			ids = append(ids, g.ID)
+		}
+	}
+	return ids, resp, nil
+}
+
+// updateDeviceGroups adds the device to and removes it from groups until its membership matches
+func updateDeviceGroups(ctx context.Context, client *iam.Client, deviceID string, toAdd, toRemove []string) error {
+	change := func(groupID string, add bool) error {
+		group, _, err := client.Groups.GetGroupByID(groupID)
+		if err != nil {
+			return fmt.Errorf("reading group '%s': %w", groupID, err)
+		}
+		return tools.TryHTTPCall(ctx, 5, func() (*http.Response, error) {
+			var resp *iam.Response
+			var err error
+			if add {
+				_, resp, err = client.Groups.AddDevices(ctx, *group, deviceID)
+			} else {
+				_, resp, err = client.Groups.RemoveDevices(ctx, *group, deviceID)
+			}
+			if resp == nil {
+				return nil, err
+			}
+			return resp.Response, err
+		})
+	}
+	for _, groupID := range toRemove {
+		if err := change(groupID, false); err != nil {
+			return fmt.Errorf("removing device from group '%s': %w", groupID, err)
+		}
+	}
+	for _, groupID := range toAdd {
+		if err := change(groupID, true); err != nil {
+			return fmt.Errorf("adding device to group '%s': %w", groupID, err)
+		}
+	}
+	return nil
+}
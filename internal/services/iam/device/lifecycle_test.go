@@ -0,0 +1,27 @@
+package device
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugUntil(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	until, err := debugUntil(now, "4h30m")
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-03-01T14:30:00Z", until)
+
+	_, err = debugUntil(now, "four hours")
+	assert.NotNil(t, err)
+}
+
+func TestValidateDebugFor(t *testing.T) {
+	_, errs := validateDebugFor("24h", "debug_for")
+	assert.Len(t, errs, 0)
+	_, errs = validateDebugFor("-1h", "debug_for")
+	assert.Len(t, errs, 1)
+	_, errs = validateDebugFor("1d", "debug_for")
+	assert.Len(t, errs, 1)
+}
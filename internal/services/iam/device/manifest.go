@@ -0,0 +1,254 @@
+package device
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/philips-software/go-dip-api/iam"
+)
+
+const (
+	manifestFormatCSV  = "csv"
+	manifestFormatJSON = "json"
+)
+
+// manifestEntry is a single device in a manufacturing manifest
+type manifestEntry struct {
+	Serial           string `json:"serial"`
+	LoginID          string `json:"login_id"`
+	Password         string `json:"password"`
+	Type             string `json:"type"`
+	ForTest          bool   `json:"for_test"`
+	ExternalSystem   string `json:"external_system"`
+	ExternalValue    string `json:"external_value"`
+	ExternalTypeCode string `json:"external_type_code"`
+	ExternalTypeText string `json:"external_type_text"`
+}
+
+// detectManifestFormat guesses the format from the content when none is given
+func detectManifestFormat(content, format string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "[") {
+		return manifestFormatJSON
+	}
+	return manifestFormatCSV
+}
+
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(strings.TrimSpace(value))
+}
+
+func parseCSVManifest(content string) ([]manifestEntry, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["serial"]; !ok {
+		return nil, fmt.Errorf("manifest is missing the 'serial' column")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var entries []manifestEntry
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest line %d: %w", line, err)
+		}
+		forTest, err := parseBool(field(record, "for_test"))
+		if err != nil {
+			return nil, fmt.Errorf("manifest line %d: invalid for_test: %w", line, err)
+		}
+		entries = append(entries, manifestEntry{
+			Serial:           field(record, "serial"),
+			LoginID:          field(record, "login_id"),
+			Password:         field(record, "password"),
+			Type:             field(record, "type"),
+			ForTest:          forTest,
+			ExternalSystem:   field(record, "external_system"),
+			ExternalValue:    field(record, "external_value"),
+			ExternalTypeCode: field(record, "external_type_code"),
+			ExternalTypeText: field(record, "external_type_text"),
+		})
+	}
+	return entries, nil
+}
+
+func parseJSONManifest(content string) ([]manifestEntry, error) {
+	var entries []manifestEntry
+	decoder := json.NewDecoder(bytes.NewBufferString(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+	return entries, nil
+}
+
+// parseManifest parses the manifest and applies the batch defaults. The result is keyed by login_id
+func parseManifest(content, format, loginIDPrefix, defaultType string) (map[string]manifestEntry, error) {
+	var entries []manifestEntry
+	var err error
+	switch detectManifestFormat(content, format) {
+	case manifestFormatCSV:
+		entries, err = parseCSVManifest(content)
+	case manifestFormatJSON:
+		entries, err = parseJSONManifest(content)
+	default:
+		return nil, fmt.Errorf("unsupported manifest format '%s'", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]manifestEntry)
+	for i, entry := range entries {
+		if entry.Serial == "" {
+			return nil, fmt.Errorf("manifest entry %d has no serial", i+1)
+		}
+		if entry.LoginID == "" {
+			entry.LoginID = loginIDPrefix + entry.Serial
+		}
+		if entry.Type == "" {
+			entry.Type = defaultType
+		}
+		if entry.Type == "" {
+			return nil, fmt.Errorf("manifest entry '%s' has no type and no default_type is set", entry.Serial)
+		}
+		if _, exists := result[entry.LoginID]; exists {
+			return nil, fmt.Errorf("duplicate login_id '%s' in manifest", entry.LoginID)
+		}
+		result[entry.LoginID] = entry
+	}
+	return result, nil
+}
+
+// fingerprint summarises the managed attributes of a device, so changes can be detected during plan
+func (e manifestEntry) fingerprint() string {
+	h := sha256.New()
+	for _, part := range []string{
+		e.LoginID, e.Password, e.Type, strconv.FormatBool(e.ForTest),
+		e.ExternalSystem, e.ExternalValue, e.ExternalTypeCode, e.ExternalTypeText,
+	} {
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// entryFromDevice converts an IAM device back into a manifest entry for comparison
+func entryFromDevice(device iam.Device, password string) manifestEntry {
+	return manifestEntry{
+		LoginID:          device.LoginID,
+		Password:         password,
+		Type:             device.Type,
+		ForTest:          device.ForTest,
+		ExternalSystem:   device.DeviceExtID.System,
+		ExternalValue:    device.DeviceExtID.Value,
+		ExternalTypeCode: device.DeviceExtID.Type.Code,
+		ExternalTypeText: device.DeviceExtID.Type.Text,
+	}
+}
+
+// toDevice converts the entry into an IAM device
+func (e manifestEntry) toDevice(organizationID, applicationID string) iam.Device {
+	return iam.Device{
+		LoginID:        e.LoginID,
+		Password:       e.Password,
+		Type:           e.Type,
+		ForTest:        e.ForTest,
+		IsActive:       true,
+		OrganizationID: organizationID,
+		ApplicationID:  applicationID,
+		DeviceExtID: iam.DeviceIdentifier{
+			System: e.ExternalSystem,
+			Value:  e.ExternalValue,
+			Type: iam.CodeableConcept{
+				Code: e.ExternalTypeCode,
+				Text: e.ExternalTypeText,
+			},
+		},
+	}
+}
+
+// batchPlan lists the login IDs to create, update and remove. Each list is sorted
+type batchPlan struct {
+	Create []string
+	Update []string
+	Remove []string
+}
+
+func planDeviceBatch(desired map[string]string, current map[string]string) batchPlan {
+	var plan batchPlan
+	for login, fingerprint := range desired {
+		currentFingerprint, ok := current[login]
+		switch {
+		case !ok:
+			plan.Create = append(plan.Create, login)
+		case currentFingerprint != fingerprint:
+			plan.Update = append(plan.Update, login)
+		}
+	}
+	for login := range current {
+		if _, ok := desired[login]; !ok {
+			plan.Remove = append(plan.Remove, login)
+		}
+	}
+	sort.Strings(plan.Create)
+	sort.Strings(plan.Update)
+	sort.Strings(plan.Remove)
+	return plan
+}
+
+// runBounded calls fn for each item with at most concurrency calls in flight.
+// The errors are returned keyed by item
+func runBounded(concurrency int, items []string, fn func(item string) error) map[string]error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(item); err != nil {
+				mu.Lock()
+				errs[item] = err
+				mu.Unlock()
+			}
+		}(item)
+	}
+	wg.Wait()
+	return errs
+}
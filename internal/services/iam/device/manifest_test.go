@@ -0,0 +1,86 @@
+package device
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseManifestCSV(t *testing.T) {
+	manifest := `serial,type,for_test,external_system,external_value
+# comment lines are skipped
+SN001,,true,urn:serial,SN001
+SN002,Gateway,false,urn:serial,SN002
+`
+	entries, err := parseManifest(manifest, "", "factory-", "Sensor")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "Sensor", entries["factory-SN001"].Type)
+	assert.True(t, entries["factory-SN001"].ForTest)
+	assert.Equal(t, "Gateway", entries["factory-SN002"].Type)
+	assert.Equal(t, "SN002", entries["factory-SN002"].ExternalValue)
+
+	_, err = parseManifest("type\nSensor\n", "csv", "", "")
+	assert.NotNil(t, err)
+	_, err = parseManifest("serial,for_test\nSN1,maybe\n", "csv", "", "Sensor")
+	assert.NotNil(t, err)
+	_, err = parseManifest("serial\nSN1\n", "csv", "", "")
+	assert.NotNil(t, err)
+}
+
+func TestParseManifestJSON(t *testing.T) {
+	manifest := `[
+  {"serial": "SN001", "login_id": "custom", "type": "Sensor"},
+  {"serial": "SN002", "type": "Sensor", "password": "Secret123!"}
+]`
+	entries, err := parseManifest(manifest, "", "", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Contains(t, entries, "custom")
+	assert.Equal(t, "Secret123!", entries["SN002"].Password)
+
+	_, err = parseManifest(`[{"serial":"A","type":"x"},{"serial":"A","type":"x"}]`, "json", "", "")
+	assert.NotNil(t, err)
+	_, err = parseManifest(`[{"serial":"A","colour":"red"}]`, "json", "", "x")
+	assert.NotNil(t, err)
+}
+
+func TestPlanDeviceBatch(t *testing.T) {
+	plan := planDeviceBatch(
+		map[string]string{"a": "1", "b": "2", "c": "3"},
+		map[string]string{"a": "1", "b": "x", "d": "4"},
+	)
+	assert.Equal(t, []string{"c"}, plan.Create)
+	assert.Equal(t, []string{"b"}, plan.Update)
+	assert.Equal(t, []string{"d"}, plan.Remove)
+}
+
+func TestRunBounded(t *testing.T) {
+	var inFlight, maxInFlight int32
+	items := make([]string, 50)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%d", i)
+	}
+	errs := runBounded(4, items, func(item string) error {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+		if item == "item-7" {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	assert.LessOrEqual(t, maxInFlight, int32(4))
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs, "item-7")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/philips-software/go-dip-api/iam"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/importer"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 )
 
@@ -21,12 +22,13 @@ var descriptions = map[string]string{
 func ResourceIAMDevice() *schema.Resource {
 	return &schema.Resource{
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateContext("device"),
 		},
 		CreateContext: resourceIAMDeviceCreate,
 		ReadContext:   resourceIAMDeviceRead,
 		UpdateContext: resourceIAMDeviceUpdate,
 		DeleteContext: resourceIAMDeviceDelete,
+		CustomizeDiff: customizeDeviceDiff,
 		Description:   descriptions["device"],
 
 		Schema: map[string]*schema.Schema{
@@ -62,9 +64,24 @@ func ResourceIAMDevice() *schema.Resource {
 				Description: "The date the device was registered.",
 			},
 			"debug_until": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"debug_for"},
+				Description:   "Debugging is enabled for the device until this time (RFC3339).",
+			},
+			"debug_for": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validateDebugFor,
+				ConflictsWith: []string{"debug_until"},
+				Description:   "Enables debugging for this duration, counted from the time of apply. Example: '4h'.",
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        tools.StringSchema(),
+				Description: "The IDs of the IAM groups this device is a member of.",
 			},
 			"text": {
 				Type:     schema.TypeString,
@@ -144,13 +161,10 @@ func schemaToDevice(d *schema.ResourceData) iam.Device {
 	deviceType := d.Get("type").(string)
 	globalReferenceId := d.Get("global_reference_id").(string)
 	forTest := false
-	isActive := true
+	isActive := isActiveSetting(d)
 	if val, ok := d.GetOk("for_test"); ok {
 		forTest = val.(bool)
 	}
-	if val, ok := d.GetOk("is_active"); ok {
-		isActive = val.(bool)
-	}
 	var deviceIdentifier iam.DeviceIdentifier
 	if v, ok := d.GetOk("external_identifier"); ok {
 		vL := v.(*schema.Set).List()
@@ -183,7 +197,7 @@ func schemaToDevice(d *schema.ResourceData) iam.Device {
 		OrganizationID:    organizationId,
 	}
 	if val, ok := d.GetOk("debug_until"); ok {
-		debugUntil, err := time.Parse(val.(string), time.RFC3339)
+		debugUntil, err := time.Parse(time.RFC3339, val.(string))
 		if err == nil {
 			resource.DebugUntil = &debugUntil
 		}
@@ -266,6 +280,9 @@ func resourceIAMDeviceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := applyDebugFor(d); err != nil {
+		return diag.FromErr(err)
+	}
 	device := schemaToDevice(d)
 	device.ID = d.Id()
 
@@ -292,6 +309,14 @@ func resourceIAMDeviceUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(fmt.Errorf("error in UpdateDevice('%s'): %w", d.Id(), err))
 	}
 	deviceToSchema(*updatedDevice, d)
+	if d.HasChange("groups") {
+		o, n := d.GetChange("groups")
+		old := tools.ExpandStringList(o.(*schema.Set).List())
+		newList := tools.ExpandStringList(n.(*schema.Set).List())
+		if err := updateDeviceGroups(ctx, client, d.Id(), tools.Difference(newList, old), tools.Difference(old, newList)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return diags
 }
 
@@ -327,6 +352,22 @@ func resourceIAMDeviceRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(fmt.Errorf("error in GetDeviceById('%s'): %w", d.Id(), err))
 	}
 	deviceToSchema(*device, d)
+	// Group membership is only read when managed here, reading groups needs extra permissions
+	if d.Get("groups").(*schema.Set).Len() > 0 {
+		groupIDs, resp, err := deviceGroupIDs(client, d.Id())
+		switch {
+		case err == nil:
+			_ = d.Set("groups", tools.SchemaSetStrings(groupIDs))
+		case resp != nil && resp.StatusCode() == http.StatusForbidden:
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("not allowed to read groups of device '%s'", d.Id()),
+				Detail:   "The group membership in the state is kept as is.",
+			})
+		default:
+			return diag.FromErr(fmt.Errorf("error reading groups of device '%s': %w", d.Id(), err))
+		}
+	}
 
 	return diags
 }
@@ -341,6 +382,9 @@ func resourceIAMDeviceCreate(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
+	if err := applyDebugFor(d); err != nil {
+		return diag.FromErr(err)
+	}
 	device := schemaToDevice(d)
 
 	if device.GlobalReferenceID == "" {
@@ -389,5 +433,11 @@ func resourceIAMDeviceCreate(ctx context.Context, d *schema.ResourceData, m inte
 	}
 	d.SetId(createdDevice.ID)
 
+	groups := tools.ExpandStringList(d.Get("groups").(*schema.Set).List())
+	if len(groups) > 0 {
+		if err := updateDeviceGroups(ctx, client, createdDevice.ID, groups, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return diags
 }
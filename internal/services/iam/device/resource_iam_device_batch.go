@@ -0,0 +1,498 @@
+package device
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+const (
+	removalActionDeactivate = "deactivate"
+	removalActionDelete     = "delete"
+
+	// inactiveFingerprint marks devices which were deactivated outside of Terraform
+	inactiveFingerprint = "inactive"
+)
+
+func ResourceIAMDeviceBatch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIAMDeviceBatchCreate,
+		ReadContext:   resourceIAMDeviceBatchRead,
+		UpdateContext: resourceIAMDeviceBatchUpdate,
+		DeleteContext: resourceIAMDeviceBatchDelete,
+		CustomizeDiff: customizeDeviceBatchDiff,
+		Description:   "Registers a batch of IAM devices from a manufacturing manifest.",
+
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The organization ID (GUID) the devices should be attached to.",
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The application ID (GUID) the devices should be attached to.",
+			},
+			"manifest": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The manifest listing the devices, in CSV or JSON format.",
+			},
+			"manifest_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{manifestFormatCSV, manifestFormatJSON}, false),
+				Description:  "The format of the manifest. Detected from the content when not set.",
+			},
+			"login_id_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Prefix for the login id of devices which have no login_id in the manifest. The serial is appended.",
+			},
+			"default_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The device type for devices which have no type in the manifest.",
+			},
+			"concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(1, 32),
+				Description:  "The maximum number of concurrent IAM calls.",
+			},
+			"removal_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      removalActionDeactivate,
+				ValidateFunc: validation.StringInSlice([]string{removalActionDeactivate, removalActionDelete}, false),
+				Description:  "What to do with devices which are removed from the manifest or when the batch is destroyed.",
+			},
+			"device_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of login id to IAM device ID.",
+			},
+			"credentials": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of login id to device password.",
+			},
+			"fingerprints": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func stringMap(raw interface{}) map[string]string {
+	result := make(map[string]string)
+	if m, ok := raw.(map[string]interface{}); ok {
+		for k, v := range m {
+			result[k] = v.(string)
+		}
+	}
+	return result
+}
+
+type batchGetter interface {
+	Get(string) interface{}
+}
+
+func batchManifest(d batchGetter) (map[string]manifestEntry, error) {
+	return parseManifest(
+		d.Get("manifest").(string),
+		d.Get("manifest_format").(string),
+		d.Get("login_id_prefix").(string),
+		d.Get("default_type").(string),
+	)
+}
+
+// desiredFingerprints returns the fingerprint of each entry, using the known
+// credentials for entries without a password in the manifest
+func desiredFingerprints(entries map[string]manifestEntry, credentials map[string]string) map[string]string {
+	fingerprints := make(map[string]string)
+	for login, entry := range entries {
+		if entry.Password == "" {
+			entry.Password = credentials[login]
+		}
+		fingerprints[login] = entry.fingerprint()
+	}
+	return fingerprints
+}
+
+func customizeDeviceBatchDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("manifest") || !d.NewValueKnown("login_id_prefix") || !d.NewValueKnown("default_type") {
+		return nil
+	}
+	entries, err := batchManifest(d)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	current := stringMap(d.Get("fingerprints"))
+	plan := planDeviceBatch(desiredFingerprints(entries, stringMap(d.Get("credentials"))), current)
+	if len(plan.Create)+len(plan.Update)+len(plan.Remove) == 0 {
+		return nil
+	}
+	for _, key := range []string{"fingerprints", "device_ids", "credentials"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deviceBatchState holds the computed maps while reconciling, safe for concurrent use
+type deviceBatchState struct {
+	mu           sync.Mutex
+	ids          map[string]string
+	credentials  map[string]string
+	fingerprints map[string]string
+}
+
+func (s *deviceBatchState) set(login, id, password, fingerprint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids[login] = id
+	s.credentials[login] = password
+	s.fingerprints[login] = fingerprint
+}
+
+func (s *deviceBatchState) remove(login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.ids, login)
+	delete(s.credentials, login)
+	delete(s.fingerprints, login)
+}
+
+func (s *deviceBatchState) id(login string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ids[login]
+}
+
+func (s *deviceBatchState) password(login string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.credentials[login]
+}
+
+func (s *deviceBatchState) write(d *schema.ResourceData) {
+	_ = d.Set("device_ids", s.ids)
+	_ = d.Set("credentials", s.credentials)
+	_ = d.Set("fingerprints", s.fingerprints)
+}
+
+func errorsToDiags(action string, errs map[string]error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	logins := make([]string, 0, len(errs))
+	for login := range errs {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	for _, login := range logins {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("failed to %s device '%s'", action, login),
+			Detail:   errs[login].Error(),
+		})
+	}
+	return diags
+}
+
+// deviceBatchClient returns the IAM client with a freshly refreshed token. The device calls run
+// concurrently and the client does not guard its token, so it is refreshed once before fanning out
+func deviceBatchClient(c *config.Config) (*iam.Client, error) {
+	client, err := c.IAMClient()
+	if err != nil {
+		return nil, err
+	}
+	_ = client.TokenRefresh()
+	return client, nil
+}
+
+func callDevice(ctx context.Context, call func() (*iam.Device, *iam.Response, error)) (*iam.Device, *iam.Response, error) {
+	var device *iam.Device
+	var resp *iam.Response
+	err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+		var err error
+		device, resp, err = call()
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	return device, resp, err
+}
+
+func createBatchDevice(ctx context.Context, client *iam.Client, device iam.Device) (*iam.Device, error) {
+	globalReferenceID, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, fmt.Errorf("error generating uuid: %w", err)
+	}
+	device.GlobalReferenceID = globalReferenceID
+	created, resp, err := callDevice(ctx, func() (*iam.Device, *iam.Response, error) {
+		return client.Devices.CreateDevice(device)
+	})
+	if err == nil && created != nil {
+		return created, nil
+	}
+	if resp == nil || resp.StatusCode() != http.StatusConflict {
+		return nil, err
+	}
+	// The device was registered before, adopt it and bring it in line with the manifest
+	existing, _, getErr := client.Devices.GetDevices(&iam.GetDevicesOptions{
+		LoginID:        &device.LoginID,
+		OrganizationID: &device.OrganizationID,
+	})
+	if getErr != nil || existing == nil || len(*existing) == 0 {
+		return nil, fmt.Errorf("GetDevices after 409: %v: %w", getErr, err)
+	}
+	device.ID = (*existing)[0].ID
+	device.GlobalReferenceID = (*existing)[0].GlobalReferenceID
+	updated, _, err := callDevice(ctx, func() (*iam.Device, *iam.Response, error) {
+		return client.Devices.UpdateDevice(device)
+	})
+	return updated, err
+}
+
+func removeBatchDevice(ctx context.Context, client *iam.Client, action, id, password string) error {
+	if action == removalActionDelete {
+		var ok bool
+		err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+			var resp *iam.Response
+			var err error
+			ok, resp, err = client.Devices.DeleteDevice(iam.Device{ID: id})
+			if resp == nil {
+				return nil, err
+			}
+			if resp.StatusCode() == http.StatusNotFound {
+				ok = true
+				return resp.Response, nil
+			}
+			return resp.Response, err
+		})
+		if err == nil && !ok {
+			err = fmt.Errorf("DeleteDevice('%s') failed", id)
+		}
+		return err
+	}
+	device, resp, err := callDevice(ctx, func() (*iam.Device, *iam.Response, error) {
+		return client.Devices.GetDeviceByID(id)
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+	device.IsActive = false
+	device.Password = password
+	_, _, err = callDevice(ctx, func() (*iam.Device, *iam.Response, error) {
+		return client.Devices.UpdateDevice(*device)
+	})
+	return err
+}
+
+// reconcileDeviceBatch registers, updates and removes devices until IAM matches the manifest
+func reconcileDeviceBatch(ctx context.Context, d *schema.ResourceData, client *iam.Client) diag.Diagnostics {
+	entries, err := batchManifest(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	organizationID := d.Get("organization_id").(string)
+	applicationID := d.Get("application_id").(string)
+	concurrency := d.Get("concurrency").(int)
+	removalAction := d.Get("removal_action").(string)
+
+	oldIDs, _ := d.GetChange("device_ids")
+	oldCredentials, _ := d.GetChange("credentials")
+	oldFingerprints, _ := d.GetChange("fingerprints")
+	state := &deviceBatchState{
+		ids:          stringMap(oldIDs),
+		credentials:  stringMap(oldCredentials),
+		fingerprints: stringMap(oldFingerprints),
+	}
+	// Resolve passwords: manifest first, then known credentials, else generate one
+	for login, entry := range entries {
+		if entry.Password == "" {
+			entry.Password = state.credentials[login]
+		}
+		if entry.Password == "" {
+			password, err := tools.RandomPassword()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			entry.Password = password
+		}
+		entries[login] = entry
+	}
+	desired := make(map[string]string)
+	for login, entry := range entries {
+		desired[login] = entry.fingerprint()
+	}
+	plan := planDeviceBatch(desired, state.fingerprints)
+
+	var diags diag.Diagnostics
+	diags = append(diags, errorsToDiags("remove", runBounded(concurrency, plan.Remove, func(login string) error {
+		if err := removeBatchDevice(ctx, client, removalAction, state.id(login), state.password(login)); err != nil {
+			return err
+		}
+		state.remove(login)
+		return nil
+	}))...)
+	diags = append(diags, errorsToDiags("update", runBounded(concurrency, plan.Update, func(login string) error {
+		entry := entries[login]
+		device := entry.toDevice(organizationID, applicationID)
+		device.ID = state.id(login)
+		updated, _, err := callDevice(ctx, func() (*iam.Device, *iam.Response, error) {
+			return client.Devices.UpdateDevice(device)
+		})
+		if err != nil {
+			return err
+		}
+		state.set(login, updated.ID, entry.Password, entry.fingerprint())
+		return nil
+	}))...)
+	diags = append(diags, errorsToDiags("register", runBounded(concurrency, plan.Create, func(login string) error {
+		entry := entries[login]
+		created, err := createBatchDevice(ctx, client, entry.toDevice(organizationID, applicationID))
+		if err != nil {
+			return err
+		}
+		if created == nil {
+			return fmt.Errorf("unexpected empty response registering '%s'", login)
+		}
+		state.set(login, created.ID, entry.Password, entry.fingerprint())
+		return nil
+	}))...)
+	// Devices changed before a failure are kept, so device_ids always shows what is registered
+	state.write(d)
+	return diags
+}
+
+func resourceIAMDeviceBatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := deviceBatchClient(c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error generating uuid: %w", err))
+	}
+	d.SetId(id)
+	diags := reconcileDeviceBatch(ctx, d, client)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, resourceIAMDeviceBatchRead(ctx, d, m)...)
+}
+
+func resourceIAMDeviceBatchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := deviceBatchClient(c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	diags := reconcileDeviceBatch(ctx, d, client)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, resourceIAMDeviceBatchRead(ctx, d, m)...)
+}
+
+func resourceIAMDeviceBatchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := deviceBatchClient(c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	state := &deviceBatchState{
+		ids:          stringMap(d.Get("device_ids")),
+		credentials:  stringMap(d.Get("credentials")),
+		fingerprints: stringMap(d.Get("fingerprints")),
+	}
+	logins := make([]string, 0, len(state.ids))
+	for login := range state.ids {
+		logins = append(logins, login)
+	}
+	errs := runBounded(d.Get("concurrency").(int), logins, func(login string) error {
+		device, resp, err := callDevice(ctx, func() (*iam.Device, *iam.Response, error) {
+			return client.Devices.GetDeviceByID(state.id(login))
+		})
+		if err != nil {
+			if resp != nil && (resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusForbidden) {
+				// Gone, the next plan registers it again
+				state.remove(login)
+				return nil
+			}
+			return err
+		}
+		fingerprint := entryFromDevice(*device, state.password(login)).fingerprint()
+		if !device.IsActive {
+			fingerprint = inactiveFingerprint
+		}
+		state.set(login, device.ID, state.password(login), fingerprint)
+		return nil
+	})
+	state.write(d)
+	return errorsToDiags("read", errs)
+}
+
+func resourceIAMDeviceBatchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := deviceBatchClient(c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	state := &deviceBatchState{
+		ids:          stringMap(d.Get("device_ids")),
+		credentials:  stringMap(d.Get("credentials")),
+		fingerprints: stringMap(d.Get("fingerprints")),
+	}
+	logins := make([]string, 0, len(state.ids))
+	for login := range state.ids {
+		logins = append(logins, login)
+	}
+	removalAction := d.Get("removal_action").(string)
+	errs := runBounded(d.Get("concurrency").(int), logins, func(login string) error {
+		if err := removeBatchDevice(ctx, client, removalAction, state.id(login), state.password(login)); err != nil {
+			return err
+		}
+		state.remove(login)
+		return nil
+	})
+	if len(errs) > 0 {
+		state.write(d)
+		return errorsToDiags("remove", errs)
+	}
+	d.SetId("")
+	return nil
+}
@@ -3,6 +3,7 @@ package email_template
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/philips-software/go-dip-api/iam"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/template_render"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 )
 
@@ -25,7 +27,9 @@ func ResourceIAMEmailTemplate() *schema.Resource {
 		},
 		CreateContext: resourceIAMEmailTemplateCreate,
 		ReadContext:   resourceIAMEmailTemplateRead,
+		UpdateContext: resourceIAMEmailTemplateUpdate,
 		DeleteContext: resourceIAMEmailTemplateDelete,
+		CustomizeDiff: template_render.ValidateTemplateDiff(template_render.KindEmail, "subject", "message"),
 
 		Schema: map[string]*schema.Schema{
 			"managing_organization": {
@@ -43,27 +47,23 @@ func ResourceIAMEmailTemplate() *schema.Resource {
 			"from": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
 				DiffSuppressFunc: tools.SuppressDefault,
 			},
 			"format": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     "HTML",
 				Description: "The template format. Must be 'HTML' currently.",
 			},
 			"subject": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     "default",
 				Description: "The Subject line of the email.",
 			},
 			"message": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "The message body.",
 			},
 			"locale": {
@@ -79,7 +79,6 @@ func ResourceIAMEmailTemplate() *schema.Resource {
 			"link": {
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
 				DiffSuppressFunc: tools.SuppressDefault,
 				Description:      "A clickable link, depends on the template type.",
 			},
@@ -91,13 +90,7 @@ func ResourceIAMEmailTemplate() *schema.Resource {
 	}
 }
 
-func resourceIAMEmailTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*config.Config)
-
-	client, err := c.IAMClient()
-	if err != nil {
-		return diag.FromErr(err)
-	}
+func schemaToEmailTemplate(d *schema.ResourceData) iam.EmailTemplate {
 	var template iam.EmailTemplate
 
 	template.Type = d.Get("type").(string)
@@ -108,10 +101,28 @@ func resourceIAMEmailTemplateCreate(ctx context.Context, d *schema.ResourceData,
 	template.Locale = d.Get("locale").(string)
 	template.From = d.Get("from").(string)
 	template.ManagingOrganization = d.Get("managing_organization").(string)
+	return template
+}
 
+// previousEmailTemplate reconstructs the template as it was before the pending changes
+func previousEmailTemplate(d *schema.ResourceData) iam.EmailTemplate {
+	template := schemaToEmailTemplate(d)
+	old := func(key string) string {
+		o, _ := d.GetChange(key)
+		return o.(string)
+	}
+	template.Format = old("format")
+	template.Subject = old("subject")
+	template.Message = base64.StdEncoding.EncodeToString([]byte(old("message")))
+	template.Link = old("link")
+	template.From = old("from")
+	return template
+}
+
+func createEmailTemplate(ctx context.Context, client *iam.Client, template iam.EmailTemplate) (*iam.EmailTemplate, error) {
 	var createdTemplate *iam.EmailTemplate
 	var resp *iam.Response
-	err = tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+	err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
 		var err error
 		createdTemplate, resp, err = client.EmailTemplates.CreateTemplate(template)
 		if resp == nil {
@@ -121,18 +132,112 @@ func resourceIAMEmailTemplateCreate(ctx context.Context, d *schema.ResourceData,
 	})
 
 	if err != nil {
-		if resp.StatusCode() == http.StatusConflict {
+		if resp != nil && resp.StatusCode() == http.StatusConflict {
 			templates, _, getErr := client.EmailTemplates.GetTemplates(&iam.GetEmailTemplatesOptions{
 				Type:           &template.Type,
 				OrganizationID: &template.ManagingOrganization,
 				Locale:         &template.Locale,
 			})
 			if getErr != nil {
-				return diag.FromErr(fmt.Errorf("createEmailTemplate HTTP 409 conflict: %w", getErr))
+				return nil, fmt.Errorf("createEmailTemplate HTTP 409 conflict: %w", getErr)
 			}
 			if len(*templates) > 0 {
-				return diag.FromErr(fmt.Errorf("conflicting template with ID '%s': %w", (*templates)[0].ID, err))
+				return nil, fmt.Errorf("%w with ID '%s': %w", config.ErrEmailTemplateConflict, (*templates)[0].ID, err)
 			}
+			return nil, fmt.Errorf("%w: %w", config.ErrEmailTemplateConflict, err)
+		}
+		return nil, err
+	}
+	return createdTemplate, nil
+}
+
+func deleteEmailTemplate(ctx context.Context, client *iam.Client, id string) error {
+	var template iam.EmailTemplate
+	template.ID = id
+	var ok bool
+	err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+		var resp *iam.Response
+		var err error
+		ok, resp, err = client.EmailTemplates.DeleteTemplate(template)
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return config.ErrDeleteEmailTemplateFailed
+	}
+	return nil
+}
+
+// replaceEmailTemplate replaces the template with the given id by next. It first tries to create next
+// alongside the old template. IAM allows a single template per type and locale, so this usually fails
+// with a conflict, in which case the old template is removed first and restored should next still
+// fail. The type and locale are without a template in between. The ID of the template which is active
+// afterwards is returned in all cases
+func replaceEmailTemplate(ctx context.Context, client *iam.Client, id string, next, previous iam.EmailTemplate) (string, *iam.EmailTemplate, error) {
+	createdTemplate, err := createEmailTemplate(ctx, client, next)
+	if err == nil {
+		if err := deleteEmailTemplate(ctx, client, id); err != nil {
+			return createdTemplate.ID, createdTemplate, fmt.Errorf("removing previous template '%s': %w", id, err)
+		}
+		return createdTemplate.ID, createdTemplate, nil
+	}
+	if !errors.Is(err, config.ErrEmailTemplateConflict) {
+		return id, nil, fmt.Errorf("creating replacement template: %w", err)
+	}
+	if err := deleteEmailTemplate(ctx, client, id); err != nil {
+		return id, nil, fmt.Errorf("removing template '%s' for update: %w", id, err)
+	}
+	createdTemplate, err = createEmailTemplate(ctx, client, next)
+	if err != nil {
+		restoredTemplate, restoreErr := createEmailTemplate(ctx, client, previous)
+		if restoreErr != nil {
+			return "", nil, fmt.Errorf("updating template: %v, restoring previous template: %w", err, restoreErr)
+		}
+		return restoredTemplate.ID, nil, fmt.Errorf("updating template, previous template restored: %w", err)
+	}
+	return createdTemplate.ID, createdTemplate, nil
+}
+
+func resourceIAMEmailTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createdTemplate, err := createEmailTemplate(ctx, client, schemaToEmailTemplate(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = d.Set("message_base64", createdTemplate.Message)
+	d.SetId(createdTemplate.ID)
+	return resourceIAMEmailTemplateRead(ctx, d, m)
+}
+
+// resourceIAMEmailTemplateUpdate replaces the template by a new one with the same type and locale,
+// as IAM has no update call for email templates. The old template is kept when the update fails.
+func resourceIAMEmailTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	activeID, createdTemplate, err := replaceEmailTemplate(ctx, client, d.Id(), schemaToEmailTemplate(d), previousEmailTemplate(d))
+	if err != nil {
+		d.SetId(activeID)
+		if createdTemplate != nil {
+			// The new template is active, only the removal of the old one failed
+			_ = d.Set("message_base64", createdTemplate.Message)
+		} else {
+			d.Partial(true)
 		}
 		return diag.FromErr(err)
 	}
@@ -178,24 +283,9 @@ func resourceIAMEmailTemplateDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	var template iam.EmailTemplate
-	template.ID = d.Id()
-	var ok bool
-	err = tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
-		var resp *iam.Response
-		var err error
-		ok, resp, err = client.EmailTemplates.DeleteTemplate(template)
-		if resp == nil {
-			return nil, err
-		}
-		return resp.Response, err
-	})
-	if err != nil {
+	if err := deleteEmailTemplate(ctx, client, d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
-	if !ok {
-		return diag.FromErr(config.ErrDeleteEmailTemplateFailed)
-	}
 	d.SetId("")
 	return diags
 }
@@ -0,0 +1,381 @@
+package email_template
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/template_render"
+)
+
+func ResourceIAMEmailTemplateSet() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the email templates of a single type for multiple locales of an HSDP IAM organization.",
+		CreateContext: resourceIAMEmailTemplateSetCreate,
+		ReadContext:   resourceIAMEmailTemplateSetRead,
+		UpdateContext: resourceIAMEmailTemplateSetUpdate,
+		DeleteContext: resourceIAMEmailTemplateSetDelete,
+		CustomizeDiff: customizeEmailTemplateSetDiff,
+
+		Schema: map[string]*schema.Schema{
+			"managing_organization": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The Id of the IAM Org to apply the email templates to.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The email template type.",
+			},
+			"format": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "HTML",
+				Description: "The template format. Must be 'HTML' currently.",
+			},
+			"from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default From field of the templates.",
+			},
+			"link": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default clickable link of the templates, depends on the template type.",
+			},
+			"default_locale": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The locale whose content is also used for the organization default template.",
+			},
+			"replace_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Remove existing templates of the same type and locale which are not managed by this resource.",
+			},
+			"template": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"locale": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The locale of the template. Use 'default' for the organization default template.",
+						},
+						"subject": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "default",
+							Description: "The Subject line of the email.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The message body.",
+						},
+						"from": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Overrides the default From field for this locale.",
+						},
+						"link": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Overrides the default link for this locale.",
+						},
+					},
+				},
+			},
+			"template_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func templateSetFromValues(get func(string) interface{}) templateSetConfig {
+	var templates []interface{}
+	if set, ok := get("template").(*schema.Set); ok && set != nil {
+		templates = set.List()
+	}
+	return templateSetConfig{
+		ManagingOrganization: get("managing_organization").(string),
+		Type:                 get("type").(string),
+		Format:               get("format").(string),
+		From:                 get("from").(string),
+		Link:                 get("link").(string),
+		DefaultLocale:        get("default_locale").(string),
+		Templates:            templates,
+	}
+}
+
+// previousTemplateSet returns the template set as it was before the pending changes
+func previousTemplateSet(d *schema.ResourceData) templateSetConfig {
+	return templateSetFromValues(func(key string) interface{} {
+		o, _ := d.GetChange(key)
+		return o
+	})
+}
+
+func customizeEmailTemplateSetDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("template") || !d.NewValueKnown("type") || !d.NewValueKnown("default_locale") {
+		return nil
+	}
+	set := templateSetFromValues(d.Get)
+	if _, err := set.templates(); err != nil {
+		return err
+	}
+	html := strings.EqualFold(set.Format, "HTML")
+	for _, raw := range set.Templates {
+		entry := raw.(map[string]interface{})
+		locale := entry["locale"].(string)
+		for _, field := range []string{"subject", "message"} {
+			text := entry[field].(string)
+			if err := template_render.ValidatePlaceholders(template_render.KindEmail, set.Type, field, text); err != nil {
+				return fmt.Errorf("template '%s': %w", locale, err)
+			}
+		}
+		if html {
+			if err := template_render.CheckHTML(entry["message"].(string)); err != nil {
+				return fmt.Errorf("template '%s': message is not well-formed HTML: %w", locale, err)
+			}
+		}
+	}
+	return nil
+}
+
+func templateIDs(d *schema.ResourceData) map[string]string {
+	ids := make(map[string]string)
+	for key, id := range d.Get("template_ids").(map[string]interface{}) {
+		ids[key] = id.(string)
+	}
+	return ids
+}
+
+// removeExistingTemplates deletes templates of the same type and locale which are not managed by us
+func removeExistingTemplates(ctx context.Context, client *iam.Client, template iam.EmailTemplate) error {
+	locale := template.Locale
+	if locale == "" {
+		locale = defaultLocale
+	}
+	existing, _, err := client.EmailTemplates.GetTemplates(&iam.GetEmailTemplatesOptions{
+		Type:           &template.Type,
+		OrganizationID: &template.ManagingOrganization,
+		Locale:         &locale,
+	})
+	if err != nil {
+		return fmt.Errorf("searching existing '%s' templates: %w", locale, err)
+	}
+	if existing == nil {
+		return nil
+	}
+	for _, e := range *existing {
+		if err := deleteEmailTemplate(ctx, client, e.ID); err != nil {
+			return fmt.Errorf("removing existing template '%s': %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
+func resourceIAMEmailTemplateSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	set := templateSetFromValues(d.Get)
+	desired, err := set.templates()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	replaceExisting := d.Get("replace_existing").(bool)
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ids := make(map[string]string)
+	d.SetId(fmt.Sprintf("%s/%s", set.ManagingOrganization, set.Type))
+	for _, key := range keys {
+		template := desired[key]
+		if replaceExisting {
+			if err := removeExistingTemplates(ctx, client, template); err != nil {
+				_ = d.Set("template_ids", ids)
+				return diag.FromErr(err)
+			}
+		}
+		createdTemplate, err := createEmailTemplate(ctx, client, template)
+		if err != nil {
+			_ = d.Set("template_ids", ids)
+			return diag.FromErr(fmt.Errorf("creating template '%s': %w", key, err))
+		}
+		ids[key] = createdTemplate.ID
+	}
+	_ = d.Set("template_ids", ids)
+	return resourceIAMEmailTemplateSetRead(ctx, d, m)
+}
+
+func resourceIAMEmailTemplateSetRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	orgID := d.Get("managing_organization").(string)
+	templateType := d.Get("type").(string)
+	remote, _, err := client.EmailTemplates.GetTemplates(&iam.GetEmailTemplatesOptions{
+		Type:           &templateType,
+		OrganizationID: &orgID,
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	remoteByID := make(map[string]iam.EmailTemplate)
+	if remote != nil {
+		for _, t := range *remote {
+			remoteByID[t.ID] = t
+		}
+	}
+
+	ids := templateIDs(d)
+	for key, id := range ids {
+		if _, ok := remoteByID[id]; !ok {
+			delete(ids, key)
+		}
+	}
+	if len(ids) == 0 {
+		d.SetId("")
+		return diags
+	}
+
+	// Drop drifted locales from state so the next plan recreates them
+	defaultFromLocale := d.Get("default_locale").(string) != ""
+	var templates []interface{}
+	for _, raw := range d.Get("template").(*schema.Set).List() {
+		entry := raw.(map[string]interface{})
+		id, ok := ids[localeKey(entry["locale"].(string))]
+		if !ok {
+			continue
+		}
+		current := remoteByID[id]
+		entry["subject"] = current.Subject
+		if message, err := base64.StdEncoding.DecodeString(current.Message); err == nil && current.Message != "" {
+			entry["message"] = string(message)
+		}
+		templates = append(templates, entry)
+	}
+	if _, ok := ids[defaultLocale]; defaultFromLocale && !ok {
+		_ = d.Set("default_locale", "")
+	}
+	_ = d.Set("template", templates)
+	_ = d.Set("template_ids", ids)
+	return diags
+}
+
+func resourceIAMEmailTemplateSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	desired, err := templateSetFromValues(d.Get).templates()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	current, err := previousTemplateSet(d).templates()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := templateIDs(d)
+	// Locales without a known template are treated as missing
+	for key := range current {
+		if _, ok := ids[key]; !ok {
+			delete(current, key)
+		}
+	}
+	changes := diffTemplateSet(current, desired)
+
+	// template_ids records the changes made so far, the next refresh reads the templates behind them
+	fail := func(err error) diag.Diagnostics {
+		_ = d.Set("template_ids", ids)
+		return diag.FromErr(err)
+	}
+	for _, key := range changes.Remove {
+		if err := deleteEmailTemplate(ctx, client, ids[key]); err != nil {
+			return fail(fmt.Errorf("removing template '%s': %w", key, err))
+		}
+		delete(ids, key)
+	}
+	for _, key := range changes.Replace {
+		activeID, _, err := replaceEmailTemplate(ctx, client, ids[key], desired[key], current[key])
+		if activeID == "" {
+			delete(ids, key)
+		} else {
+			ids[key] = activeID
+		}
+		if err != nil {
+			return fail(fmt.Errorf("template '%s': %w", key, err))
+		}
+	}
+	replaceExisting := d.Get("replace_existing").(bool)
+	for _, key := range changes.Create {
+		if replaceExisting {
+			if err := removeExistingTemplates(ctx, client, desired[key]); err != nil {
+				return fail(err)
+			}
+		}
+		createdTemplate, err := createEmailTemplate(ctx, client, desired[key])
+		if err != nil {
+			return fail(fmt.Errorf("creating template '%s': %w", key, err))
+		}
+		ids[key] = createdTemplate.ID
+	}
+	_ = d.Set("template_ids", ids)
+	return resourceIAMEmailTemplateSetRead(ctx, d, m)
+}
+
+func resourceIAMEmailTemplateSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := templateIDs(d)
+	for key, id := range ids {
+		if err := deleteEmailTemplate(ctx, client, id); err != nil {
+			_ = d.Set("template_ids", ids)
+			return diag.FromErr(fmt.Errorf("removing template '%s': %w", key, err))
+		}
+		delete(ids, key)
+	}
+	d.SetId("")
+	return diags
+}
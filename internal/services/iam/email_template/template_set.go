@@ -0,0 +1,124 @@
+package email_template
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/philips-software/go-dip-api/iam"
+)
+
+// defaultLocale is the key of the organization default template, i.e. the template without a locale
+const defaultLocale = "default"
+
+// templateSetConfig holds the settings of a hsdp_iam_email_template_set at one point in time
+type templateSetConfig struct {
+	ManagingOrganization string
+	Type                 string
+	Format               string
+	From                 string
+	Link                 string
+	DefaultLocale        string
+	Templates            []interface{}
+}
+
+// isDefaultLocale returns true when locale refers to the organization default template
+func isDefaultLocale(locale string) bool {
+	return locale == "" || strings.EqualFold(locale, defaultLocale)
+}
+
+// localeKey normalises a locale for use as a key
+func localeKey(locale string) string {
+	if isDefaultLocale(locale) {
+		return defaultLocale
+	}
+	return strings.ToLower(locale)
+}
+
+// templates expands the configuration into the IAM templates it represents, keyed by locale.
+// When DefaultLocale is set the content of that locale is also used for the default template
+func (s templateSetConfig) templates() (map[string]iam.EmailTemplate, error) {
+	set := make(map[string]iam.EmailTemplate)
+	for _, raw := range s.Templates {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		locale := entry["locale"].(string)
+		key := localeKey(locale)
+		if _, exists := set[key]; exists {
+			return nil, fmt.Errorf("duplicate template for locale '%s'", locale)
+		}
+		template := iam.EmailTemplate{
+			Type:                 s.Type,
+			ManagingOrganization: s.ManagingOrganization,
+			Format:               s.Format,
+			From:                 s.From,
+			Link:                 s.Link,
+			Subject:              entry["subject"].(string),
+			Message:              base64.StdEncoding.EncodeToString([]byte(entry["message"].(string))),
+		}
+		if !isDefaultLocale(locale) {
+			template.Locale = locale
+		}
+		if from, ok := entry["from"].(string); ok && from != "" {
+			template.From = from
+		}
+		if link, ok := entry["link"].(string); ok && link != "" {
+			template.Link = link
+		}
+		set[key] = template
+	}
+	if s.DefaultLocale == "" {
+		return set, nil
+	}
+	if _, exists := set[defaultLocale]; exists {
+		return nil, fmt.Errorf("default_locale '%s' conflicts with the template for locale '%s'", s.DefaultLocale, defaultLocale)
+	}
+	source, ok := set[localeKey(s.DefaultLocale)]
+	if !ok {
+		return nil, fmt.Errorf("default_locale '%s' does not match any template locale", s.DefaultLocale)
+	}
+	source.Locale = ""
+	set[defaultLocale] = source
+	return set, nil
+}
+
+// templateSetChanges lists the locales which need to be removed, replaced and created
+// to turn the current set into the desired set. Each list is sorted.
+type templateSetChanges struct {
+	Remove  []string
+	Replace []string
+	Create  []string
+}
+
+func sameEmailTemplate(a, b iam.EmailTemplate) bool {
+	return a.Subject == b.Subject &&
+		a.Message == b.Message &&
+		a.Format == b.Format &&
+		a.From == b.From &&
+		a.Link == b.Link
+}
+
+func diffTemplateSet(current, desired map[string]iam.EmailTemplate) templateSetChanges {
+	var changes templateSetChanges
+	for key, template := range current {
+		want, ok := desired[key]
+		switch {
+		case !ok:
+			changes.Remove = append(changes.Remove, key)
+		case !sameEmailTemplate(template, want):
+			changes.Replace = append(changes.Replace, key)
+		}
+	}
+	for key := range desired {
+		if _, ok := current[key]; !ok {
+			changes.Create = append(changes.Create, key)
+		}
+	}
+	sort.Strings(changes.Remove)
+	sort.Strings(changes.Replace)
+	sort.Strings(changes.Create)
+	return changes
+}
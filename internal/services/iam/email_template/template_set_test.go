@@ -0,0 +1,72 @@
+package email_template
+
+import (
+	"testing"
+
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/stretchr/testify/assert"
+)
+
+func entry(locale, subject, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"locale":  locale,
+		"subject": subject,
+		"message": message,
+		"from":    "",
+		"link":    "",
+	}
+}
+
+func TestTemplateSetTemplates(t *testing.T) {
+	set := templateSetConfig{
+		ManagingOrganization: "org",
+		Type:                 "PASSWORD_CHANGED",
+		Format:               "HTML",
+		From:                 "noreply@example.com",
+		DefaultLocale:        "en-US",
+		Templates: []interface{}{
+			entry("en-US", "Password changed", "Hello"),
+			entry("nl-NL", "Wachtwoord gewijzigd", "Hallo"),
+		},
+	}
+	templates, err := set.templates()
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, templates, 3)
+	assert.Equal(t, "", templates["default"].Locale)
+	assert.Equal(t, "Password changed", templates["default"].Subject)
+	assert.Equal(t, "nl-NL", templates["nl-nl"].Locale)
+	assert.Equal(t, "noreply@example.com", templates["nl-nl"].From)
+
+	set.DefaultLocale = "fr-FR"
+	_, err = set.templates()
+	assert.NotNil(t, err)
+
+	set.DefaultLocale = "en-US"
+	set.Templates = append(set.Templates, entry("default", "Default", "Hi"))
+	_, err = set.templates()
+	assert.NotNil(t, err)
+
+	set.DefaultLocale = ""
+	set.Templates = append(set.Templates, entry("NL-nl", "Dup", "Dup"))
+	_, err = set.templates()
+	assert.NotNil(t, err)
+}
+
+func TestDiffTemplateSet(t *testing.T) {
+	current := map[string]iam.EmailTemplate{
+		"default": {Subject: "a", Message: "m"},
+		"en-us":   {Subject: "a", Message: "m"},
+		"de-de":   {Subject: "a", Message: "m"},
+	}
+	desired := map[string]iam.EmailTemplate{
+		"default": {Subject: "a", Message: "m"},
+		"en-us":   {Subject: "b", Message: "m"},
+		"nl-nl":   {Subject: "a", Message: "m"},
+	}
+	changes := diffTemplateSet(current, desired)
+	assert.Equal(t, []string{"de-de"}, changes.Remove)
+	assert.Equal(t, []string{"en-us"}, changes.Replace)
+	assert.Equal(t, []string{"nl-nl"}, changes.Create)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/philips-software/go-dip-api/iam"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/importer"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 )
 
@@ -21,7 +22,7 @@ func ResourceIAMGroup() *schema.Resource {
 	return &schema.Resource{
 		Description: descriptions["group"],
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateContext("group"),
 		},
 		SchemaVersion: 4,
 		CreateContext: resourceIAMGroupCreate,
@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+// Path is a friendly import ID of the form <parentKind>/<parentID>/<kind>/<name>
+type Path struct {
+	ParentKind string
+	ParentID   string
+	Kind       string
+	Name       string
+}
+
+func (p Path) String() string {
+	return fmt.Sprintf("%s/%s/%s/%s", p.ParentKind, p.ParentID, p.Kind, p.Name)
+}
+
+// ParseImportID parses a friendly import ID. It returns nil when id is a plain ID.
+// The name is the remainder of the ID, so it may contain slashes
+func ParseImportID(id string) (*Path, error) {
+	if !strings.Contains(id, "/") {
+		return nil, nil
+	}
+	parts := strings.SplitN(id, "/", 4)
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		return nil, fmt.Errorf("invalid import ID '%s', expected <parent>/<parent ID>/<kind>/<name>", id)
+	}
+	return &Path{
+		ParentKind: parts[0],
+		ParentID:   parts[1],
+		Kind:       parts[2],
+		Name:       parts[3],
+	}, nil
+}
+
+type lookupFunc func(client *iam.Client, parentID, name string) ([]string, error)
+
+// lookups lists the supported friendly import formats by kind and parent kind
+var lookups = map[string]map[string]lookupFunc{
+	"org":         {"org": findOrganizations},
+	"group":       {"org": findGroups},
+	"role":        {"org": findRoles},
+	"proposition": {"org": findPropositions},
+	"device":      {"org": findDevices},
+	"application": {"proposition": findApplications},
+	"service":     {"application": findServices},
+	"client":      {"application": findClients},
+}
+
+// Formats returns the supported friendly import formats for kind
+func Formats(kind string) []string {
+	var formats []string
+	for parentKind := range lookups[kind] {
+		formats = append(formats, fmt.Sprintf("%s/<%s ID>/%s/<name>", parentKind, parentKind, kind))
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Resolve returns the ID of the resource of the given kind referenced by importID
+func Resolve(client *iam.Client, kind, importID string) (string, error) {
+	path, err := ParseImportID(importID)
+	if err != nil {
+		return "", err
+	}
+	if path == nil {
+		return importID, nil
+	}
+	lookup, ok := lookups[path.Kind][path.ParentKind]
+	if !ok || path.Kind != kind {
+		return "", fmt.Errorf("unsupported import ID '%s', supported formats: <ID>, %s", importID, strings.Join(Formats(kind), ", "))
+	}
+	ids, err := lookup(client, path.ParentID, path.Name)
+	if err != nil {
+		return "", fmt.Errorf("looking up %s: %w", path, err)
+	}
+	return singleMatch(path, ids)
+}
+
+func singleMatch(path *Path, ids []string) (string, error) {
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named '%s' found in %s '%s'", path.Kind, path.Name, path.ParentKind, path.ParentID)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d %ss named '%s' found in %s '%s', import by ID instead", len(ids), path.Kind, path.Name, path.ParentKind, path.ParentID)
+	}
+}
+
+// StateContext returns an importer for the given kind which accepts both plain IDs and friendly import IDs
+func StateContext(kind string) schema.StateContextFunc {
+	return func(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		importID, err := url.QueryUnescape(d.Id()) // Can originate from Crossplane
+		if err != nil {
+			return nil, fmt.Errorf("url.QueryUnescape error: %w", err)
+		}
+		if !strings.Contains(importID, "/") {
+			d.SetId(importID)
+			return []*schema.ResourceData{d}, nil
+		}
+		c := m.(*config.Config)
+		client, err := c.IAMClient()
+		if err != nil {
+			return nil, fmt.Errorf("IAMClient error: %w", err)
+		}
+		id, err := Resolve(client, kind, importID)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,51 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportID(t *testing.T) {
+	path, err := ParseImportID("c8f6d1a2-0000-4d6f-9b7e-1d2c3b4a5f60")
+	assert.Nil(t, err)
+	assert.Nil(t, path)
+
+	path, err = ParseImportID("org/abc/group/My Group/With Slash")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "org", path.ParentKind)
+	assert.Equal(t, "abc", path.ParentID)
+	assert.Equal(t, "group", path.Kind)
+	assert.Equal(t, "My Group/With Slash", path.Name)
+
+	_, err = ParseImportID("org/abc/group")
+	assert.NotNil(t, err)
+	_, err = ParseImportID("org//group/name")
+	assert.NotNil(t, err)
+}
+
+func TestResolveUnsupported(t *testing.T) {
+	id, err := Resolve(nil, "group", "plain-id")
+	assert.Nil(t, err)
+	assert.Equal(t, "plain-id", id)
+
+	_, err = Resolve(nil, "group", "proposition/abc/group/name")
+	assert.NotNil(t, err)
+	_, err = Resolve(nil, "service", "org/abc/group/name")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "application/<application ID>/service/<name>")
+	}
+}
+
+func TestSingleMatch(t *testing.T) {
+	path := &Path{ParentKind: "org", ParentID: "abc", Kind: "role", Name: "ADMIN"}
+	_, err := singleMatch(path, nil)
+	assert.NotNil(t, err)
+	id, err := singleMatch(path, []string{"r1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "r1", id)
+	_, err = singleMatch(path, []string{"r1", "r2"})
+	assert.NotNil(t, err)
+}
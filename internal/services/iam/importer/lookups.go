@@ -0,0 +1,138 @@
+package importer
+
+import (
+	"github.com/philips-software/go-dip-api/iam"
+)
+
+// The IAM name filters are not guaranteed to be exact, so every lookup keeps only exact matches and leaves
+// ambiguity to singleMatch
+
+func findOrganizations(client *iam.Client, parentID, name string) ([]string, error) {
+	orgs, _, err := client.Organizations.GetOrganizations(&iam.GetOrganizationOptions{
+		ParentOrgID: &parentID,
+		Name:        &name,
+	})
+	if err != nil || orgs == nil {
+		return nil, err
+	}
+	var ids []string
+	for _, org := range *orgs {
+		if org.Name == name && org.ID != parentID {
+			ids = append(ids, org.ID)
+		}
+	}
+	return ids, nil
+}
+
+func findGroups(client *iam.Client, orgID, name string) ([]string, error) {
+	groups, _, err := client.Groups.GetGroups(&iam.GetGroupOptions{
+		OrganizationID: &orgID,
+		Name:           &name,
+	})
+	if err != nil || groups == nil {
+		return nil, err
+	}
+	var ids []string
+	for _, group := range *groups {
+		if group.Name == name {
+			ids = append(ids, group.ID)
+		}
+	}
+	return ids, nil
+}
+
+func findRoles(client *iam.Client, orgID, name string) ([]string, error) {
+	roles, _, err := client.Roles.GetRoles(&iam.GetRolesOptions{
+		OrganizationID: &orgID,
+		Name:           &name,
+	})
+	if err != nil || roles == nil {
+		return nil, err
+	}
+	var ids []string
+	for _, role := range *roles {
+		if role.Name == name {
+			ids = append(ids, role.ID)
+		}
+	}
+	return ids, nil
+}
+
+func findPropositions(client *iam.Client, orgID, name string) ([]string, error) {
+	prop, _, err := client.Propositions.GetProposition(&iam.GetPropositionsOptions{
+		OrganizationID: &orgID,
+		Name:           &name,
+	})
+	if err != nil || prop == nil || prop.Name != name {
+		return nil, err
+	}
+	return []string{prop.ID}, nil
+}
+
+func findDevices(client *iam.Client, orgID, loginID string) ([]string, error) {
+	devices, _, err := client.Devices.GetDevices(&iam.GetDevicesOptions{
+		OrganizationID: &orgID,
+		LoginID:        &loginID,
+	})
+	if err != nil || devices == nil {
+		return nil, err
+	}
+	var ids []string
+	for _, device := range *devices {
+		if device.LoginID == loginID {
+			ids = append(ids, device.ID)
+		}
+	}
+	return ids, nil
+}
+
+func findApplications(client *iam.Client, propositionID, name string) ([]string, error) {
+	apps, _, err := client.Applications.GetApplications(&iam.GetApplicationsOptions{
+		PropositionID: &propositionID,
+		Name:          &name,
+	})
+	if err != nil || apps == nil {
+		return nil, err
+	}
+	var ids []string
+	for _, app := range apps {
+		if app.Name == name {
+			ids = append(ids, app.ID)
+		}
+	}
+	return ids, nil
+}
+
+func findServices(client *iam.Client, applicationID, name string) ([]string, error) {
+	services, _, err := client.Services.GetServices(&iam.GetServiceOptions{
+		ApplicationID: &applicationID,
+		Name:          &name,
+	})
+	if err != nil || services == nil {
+		return nil, err
+	}
+	var ids []string
+	for _, service := range *services {
+		if service.Name == name {
+			ids = append(ids, service.ID)
+		}
+	}
+	return ids, nil
+}
+
+func findClients(client *iam.Client, applicationID, name string) ([]string, error) {
+	clients, _, err := client.Clients.GetClients(&iam.GetClientsOptions{
+		ApplicationID: &applicationID,
+		Name:          &name,
+	})
+	if err != nil || clients == nil {
+		return nil, err
+	}
+	var ids []string
+	for _, c := range *clients {
+		if c.Name == name {
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+func DataSourceIAMOrgHierarchy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the subtree of organizations below an IAM organization.",
+		ReadContext: dataSourceIAMOrgHierarchyRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"max_depth": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"organizations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"external_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_org_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"depth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIAMOrgHierarchyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*config.Config)
+
+	var diags diag.Diagnostics
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	orgID := d.Get("organization_id").(string)
+	maxDepth := d.Get("max_depth").(int)
+
+	nodes, err := WalkOrgTree(ctx, client, orgID, maxDepth)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, 0, len(nodes))
+	orgs := make([]map[string]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+		orgs = append(orgs, map[string]interface{}{
+			"id":            node.ID,
+			"name":          node.Name,
+			"type":          node.Type,
+			"external_id":   node.ExternalID,
+			"parent_org_id": node.ParentOrgID,
+			"depth":         node.Depth,
+		})
+	}
+	d.SetId(orgID)
+	_ = d.Set("ids", ids)
+	_ = d.Set("organizations", orgs)
+
+	return diags
+}
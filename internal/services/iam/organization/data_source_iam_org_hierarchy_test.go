@@ -0,0 +1,63 @@
+package organization_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/philips-software/terraform-provider-hsdp/internal/acc"
+)
+
+func TestAccDataSourceIAMOrgHierarchy_basic(t *testing.T) {
+	t.Parallel()
+
+	parentOrgID := acc.AccIAMOrgGUID()
+	randomOrgName := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acc.PreCheck(t)
+		},
+		ProviderFactories: acc.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: "data.hsdp_iam_org_hierarchy.test",
+				Config:       testAccDataSourceIAMOrgHierarchy(parentOrgID, randomOrgName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hsdp_iam_org_hierarchy.test", "organizations.#", "2"),
+					resource.TestCheckResourceAttr("data.hsdp_iam_org_hierarchy.test", "organizations.0.depth", "1"),
+					resource.TestCheckResourceAttr("data.hsdp_iam_org_hierarchy.test", "organizations.1.depth", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIAMOrgHierarchy(parentOrgID, name string) string {
+	return fmt.Sprintf(`
+resource "hsdp_iam_org" "top" {
+  name           = "ACCTest-%s"
+  description    = "ACC Test Org %s"
+  parent_org_id  = "%s"
+  cascade_delete = true
+}
+
+resource "hsdp_iam_org" "child" {
+  name          = "ACCTest-%s-child"
+  description   = "ACC Test Child Org %s"
+  parent_org_id = hsdp_iam_org.top.id
+}
+
+resource "hsdp_iam_org" "grandchild" {
+  name          = "ACCTest-%s-grandchild"
+  description   = "ACC Test Grandchild Org %s"
+  parent_org_id = hsdp_iam_org.child.id
+}
+
+data "hsdp_iam_org_hierarchy" "test" {
+  organization_id = hsdp_iam_org.top.id
+
+  depends_on = [hsdp_iam_org.grandchild]
+}`, name, name, parentOrgID, name, name, name, name)
+}
@@ -0,0 +1,159 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+const orgSearchPageSize = 100
+
+// OrgNode is a single organization in a hierarchy together with its position
+type OrgNode struct {
+	ID          string
+	Name        string
+	Type        string
+	ExternalID  string
+	ParentOrgID string
+	Depth       int
+}
+
+// childOrgs returns all direct children of parentID, following IAM search paging
+func childOrgs(ctx context.Context, client *iam.Client, parentID string) ([]iam.Organization, error) {
+	var children []iam.Organization
+
+	count := orgSearchPageSize
+	startIndex := 1
+	for {
+		var orgs *[]iam.Organization
+		var resp *iam.Response
+		err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+			var err error
+			orgs, resp, err = client.Organizations.GetOrganizations(&iam.GetOrganizationOptions{
+				ParentOrgID: &parentID,
+				Count:       &count,
+				StartIndex:  &startIndex,
+			})
+			if resp == nil {
+				return nil, err
+			}
+			return resp.Response, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("searching children of organization '%s': %w", parentID, err)
+		}
+		if orgs == nil {
+			break
+		}
+		for _, org := range *orgs {
+			if org.ID == parentID { // Guard against the parent being echoed back
+				continue
+			}
+			children = append(children, org)
+		}
+		if len(*orgs) < count {
+			break
+		}
+		startIndex += count
+	}
+	return children, nil
+}
+
+// WalkOrgTree collects the subtree below rootID in breadth-first order.
+// A maxDepth of 0 means there is no depth limit. The root itself is not included.
+func WalkOrgTree(ctx context.Context, client *iam.Client, rootID string, maxDepth int) ([]OrgNode, error) {
+	var nodes []OrgNode
+
+	seen := map[string]bool{rootID: true}
+	queue := []OrgNode{{ID: rootID}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if maxDepth > 0 && current.Depth >= maxDepth {
+			continue
+		}
+		children, err := childOrgs(ctx, client, current.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			node := OrgNode{
+				ID:          child.ID,
+				Name:        child.Name,
+				Type:        child.Type,
+				ExternalID:  child.ExternalID,
+				ParentOrgID: current.ID,
+				Depth:       current.Depth + 1,
+			}
+			nodes = append(nodes, node)
+			queue = append(queue, node)
+		}
+	}
+	return nodes, nil
+}
+
+// deleteOrgTree removes all organizations below rootID, deepest first.
+// It refuses to remove an organization that still has users.
+func deleteOrgTree(ctx context.Context, client *iam.Client, rootID string, waitForDelete bool, timeout time.Duration) error {
+	nodes, err := WalkOrgTree(ctx, client, rootID, 0)
+	if err != nil {
+		return err
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		users, _, err := client.Users.GetAllUsers(&iam.GetUserOptions{
+			OrganizationID: &node.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("checking users of child organization '%s': %w", node.Name, err)
+		}
+		if len(users) > 0 {
+			return fmt.Errorf("child organization '%s' (%s) is not empty: it still has %d user(s)", node.Name, node.ID, len(users))
+		}
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if err := deleteOrg(ctx, client, nodes[i].ID, waitForDelete, timeout); err != nil {
+			return fmt.Errorf("deleting child organization '%s': %w", nodes[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func deleteOrg(ctx context.Context, client *iam.Client, id string, waitForDelete bool, timeout time.Duration) error {
+	org, _, err := client.Organizations.GetOrganizationByID(id)
+	if err != nil {
+		return err
+	}
+	ok, _, err := client.Organizations.DeleteOrganization(*org)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("delete of organization '%s' was not accepted", id)
+	}
+	if !waitForDelete {
+		return nil
+	}
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{"IN_PROGRESS", "QUEUED", "indeterminate"},
+		Target:     []string{"SUCCESS"},
+		Refresh:    checkOrgDeleteStatus(client, id),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: time.Duration(5) * time.Second,
+	}
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for delete: %w", err)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/philips-software/go-dip-api/iam"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/importer"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -24,7 +25,7 @@ func ResourceIAMOrg() *schema.Resource {
 	return &schema.Resource{
 		Description: descriptions["organization"],
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateContext("org"),
 		},
 		SchemaVersion: 4,
 		CreateContext: resourceIAMOrgCreate,
@@ -75,6 +76,11 @@ func ResourceIAMOrg() *schema.Resource {
 				Optional:    true,
 				Description: "Blocks until the organization delete has completed. Default: false. The organization delete process can take some time as all its associated resources like users, groups, roles etc. are removed recursively. This option is useful for ephemeral environments where the same organization might be recreated shortly after a destroy operation.",
 			},
+			"cascade_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Delete all child organizations, deepest first, before deleting this organization. Child organizations which still have users block the delete. Honors wait_for_delete for every child. Default: false.",
+			},
 			"active": {
 				Type:        schema.TypeBool,
 				Computed:    true,
@@ -218,6 +224,13 @@ func resourceIAMOrgDelete(ctx context.Context, d *schema.ResourceData, m interfa
 	}
 	waitForDelete := d.Get("wait_for_delete").(bool)
 
+	if d.Get("cascade_delete").(bool) {
+		err = deleteOrgTree(ctx, client, id, waitForDelete, d.Timeout(schema.TimeoutDelete))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("cascade delete: %w", err))
+		}
+	}
+
 	ok, _, err := client.Organizations.DeleteOrganization(*org)
 	if err != nil {
 		return diag.FromErr(err)
@@ -0,0 +1,70 @@
+package iam
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+)
+
+// maxOrgChainDepth bounds the parent walk, guarding against misconfigured hierarchies
+const maxOrgChainDepth = 32
+
+// walkOrgChain calls visit for startOrgID and then for each of its ancestors, until visit
+// reports a match. It returns the ID of the matching organization or "" when none matched, and an error
+// when the chain is longer than maxOrgChainDepth
+func walkOrgChain(startOrgID string, parentOf func(orgID string) (string, error), visit func(orgID string) (bool, error)) (string, error) {
+	seen := make(map[string]bool)
+	orgID := startOrgID
+	for depth := 0; orgID != ""; depth++ {
+		if depth >= maxOrgChainDepth {
+			return "", fmt.Errorf("organization hierarchy of '%s' is deeper than %d levels", startOrgID, maxOrgChainDepth)
+		}
+		if seen[orgID] {
+			return "", fmt.Errorf("cycle detected in organization hierarchy at '%s'", orgID)
+		}
+		seen[orgID] = true
+		found, err := visit(orgID)
+		if err != nil {
+			return "", err
+		}
+		if found {
+			return orgID, nil
+		}
+		parent, err := parentOf(orgID)
+		if err != nil {
+			return "", err
+		}
+		orgID = parent
+	}
+	return "", nil
+}
+
+// orgParentFunc returns a parentOf function for walkOrgChain backed by IAM
+func orgParentFunc(client *iam.Client) func(string) (string, error) {
+	return func(orgID string) (string, error) {
+		org, _, err := client.Organizations.GetOrganizationByID(orgID)
+		if err != nil {
+			return "", fmt.Errorf("reading organization '%s': %w", orgID, err)
+		}
+		if org.Parent.Value == orgID {
+			return "", nil
+		}
+		return org.Parent.Value, nil
+	}
+}
+
+// policySubject resolves the organization to start the policy lookup from.
+// When a user_id is given the managing organization of the user is used
+func policySubject(client *iam.Client, d *schema.ResourceData) (userID string, orgID string, err error) {
+	userID = d.Get("user_id").(string)
+	orgID = d.Get("organization_id").(string)
+	if userID == "" {
+		return "", orgID, nil
+	}
+	user, _, err := client.Users.GetUserByID(userID)
+	if err != nil {
+		return "", "", fmt.Errorf("reading user '%s': %w", userID, err)
+	}
+	return userID, user.ManagingOrganization, nil
+}
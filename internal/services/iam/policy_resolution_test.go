@@ -0,0 +1,49 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkOrgChain(t *testing.T) {
+	parents := map[string]string{
+		"child":  "parent",
+		"parent": "root",
+		"root":   "",
+		"loopA":  "loopB",
+		"loopB":  "loopA",
+	}
+	parentOf := func(id string) (string, error) { return parents[id], nil }
+	hasPolicy := func(ids ...string) func(string) (bool, error) {
+		return func(id string) (bool, error) {
+			for _, i := range ids {
+				if i == id {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	}
+
+	source, err := walkOrgChain("child", parentOf, hasPolicy("child", "root"))
+	assert.Nil(t, err)
+	assert.Equal(t, "child", source)
+
+	source, err = walkOrgChain("child", parentOf, hasPolicy("root"))
+	assert.Nil(t, err)
+	assert.Equal(t, "root", source)
+
+	source, err = walkOrgChain("child", parentOf, hasPolicy())
+	assert.Nil(t, err)
+	assert.Equal(t, "", source)
+
+	_, err = walkOrgChain("loopA", parentOf, hasPolicy())
+	assert.NotNil(t, err)
+
+	deep := func(id string) (string, error) { return id + "x", nil }
+	_, err = walkOrgChain("org", deep, hasPolicy())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "deeper than")
+	}
+}
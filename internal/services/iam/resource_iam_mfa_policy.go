@@ -46,13 +46,11 @@ func ResourceIAMMFAPolicy() *schema.Resource {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"organization"},
-				ForceNew:      true,
 			},
 			"organization": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ConflictsWith: []string{"user"},
-				ForceNew:      true,
 			},
 			"version": {
 				Type:     schema.TypeString,
@@ -130,8 +128,10 @@ func resourceIAMMFAPolicyRead(_ context.Context, d *schema.ResourceData, m inter
 	switch policy.Resource.Type {
 	case "User":
 		_ = d.Set("user", policy.Resource.Value)
+		_ = d.Set("organization", "")
 	case "Organization":
 		_ = d.Set("organization", policy.Resource.Value)
+		_ = d.Set("user", "")
 	}
 	_ = d.Set("version", policy.Meta.Version)
 
@@ -166,6 +166,16 @@ func resourceIAMMFAPolicyUpdate(_ context.Context, d *schema.ResourceData, m int
 		active := d.Get("active").(bool)
 		policy.Active = &active
 	}
+	if d.HasChanges("user", "organization") {
+		user := d.Get("user").(string)
+		organization := d.Get("organization").(string)
+		// Move the policy to its new scope in-place
+		if user != "" {
+			policy.SetResourceUser(user)
+		} else {
+			policy.SetResourceOrganization(organization)
+		}
+	}
 	updatedPolicy, _, err := client.MFAPolicies.UpdateMFAPolicy(policy)
 	if err != nil {
 		diags = append(diags, diag.FromErr(err)...)
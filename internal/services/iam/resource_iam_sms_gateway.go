@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -54,6 +55,33 @@ func ResourceIAMSMSGatewayConfig() *schema.Resource {
 				MaxItems: 1,
 				Elem:     smsCredentialsSchema(),
 			},
+			"verify": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"check_credentials": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"test_number": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"test_message": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "HSDP IAM SMS gateway test message",
+						},
+					},
+				},
+			},
+			"credentials_rotated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"query_retrieve_service_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -66,8 +94,9 @@ func smsCredentialsSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"token": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 		},
 	}
@@ -113,6 +142,12 @@ func schemaWriteSMSGateway(s iam.SMSGateway, d *schema.ResourceData) error {
 	}
 	credentials := make(map[string]interface{})
 	credentials["token"] = s.Credentials.Token
+	if s.Credentials.Token == "" {
+		// IAM does not return the token, so keep the one we know of
+		if current, err := schemaReadSMSGateway(d); err == nil {
+			credentials["token"] = current.Credentials.Token
+		}
+	}
 	c := &schema.Set{F: schema.HashResource(smsCredentialsSchema())}
 	c.Add(credentials)
 	return d.Set("credentials", c)
@@ -171,6 +206,12 @@ func resourceIAMSMSGatewayConfigUpdate(ctx context.Context, d *schema.ResourceDa
 	}
 	gw.ID = id
 	gw.Meta = serverVersion.Meta
+	var verifyDiags diag.Diagnostics
+	if d.HasChanges("properties", "credentials", "verify") {
+		if verifyDiags = verifySMSGateway(ctx, d, *gw); verifyDiags.HasError() {
+			return verifyDiags
+		}
+	}
 	err = tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
 		var err error
 		gw, resp, err = client.SMSGateways.UpdateSMSGateway(*gw)
@@ -182,7 +223,10 @@ func resourceIAMSMSGatewayConfigUpdate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error updating SMS gateway: %w", err))
 	}
-	return resourceIAMSMSGatewayConfigRead(ctx, d, meta)
+	if d.HasChange("credentials") {
+		_ = d.Set("credentials_rotated_at", time.Now().UTC().Format(time.RFC3339))
+	}
+	return append(verifyDiags, resourceIAMSMSGatewayConfigRead(ctx, d, meta)...)
 }
 
 func resourceIAMSMSGatewayConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -259,6 +303,10 @@ func resourceIAMSMSGatewayConfigCreate(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error reading SMS gateway: %w", err))
 	}
+	verifyDiags := verifySMSGateway(ctx, d, *gw)
+	if verifyDiags.HasError() {
+		return verifyDiags
+	}
 	err = tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
 		var err error
 		createdGW, resp, err = client.SMSGateways.CreateSMSGateway(*gw)
@@ -271,5 +319,5 @@ func resourceIAMSMSGatewayConfigCreate(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(fmt.Errorf("error creating SMS gateway: %w", err))
 	}
 	d.SetId(createdGW.ID)
-	return resourceIAMSMSGatewayConfigRead(ctx, d, meta)
+	return append(verifyDiags, resourceIAMSMSGatewayConfigRead(ctx, d, meta)...)
 }
@@ -8,8 +8,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/philips-software/go-dip-api/iam"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/template_render"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 )
 
@@ -20,7 +22,9 @@ func ResourceIAMSMSTemplate() *schema.Resource {
 		},
 		CreateContext: resourceIAMSMSTemplateCreate,
 		ReadContext:   resourceIAMSMSTemplateRead,
+		UpdateContext: resourceIAMSMSTemplateUpdate,
 		DeleteContext: resourceIAMSMSTemplateDelete,
+		CustomizeDiff: customizeSMSTemplateDiff,
 		SchemaVersion: 1,
 
 		Schema: map[string]*schema.Schema{
@@ -32,7 +36,11 @@ func ResourceIAMSMSTemplate() *schema.Resource {
 			"message": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+			},
+			"max_segments": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"type": {
 				Type:     schema.TypeString,
@@ -42,7 +50,6 @@ func ResourceIAMSMSTemplate() *schema.Resource {
 			"external_id": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 			"locale": {
 				Type:     schema.TypeString,
@@ -50,10 +57,52 @@ func ResourceIAMSMSTemplate() *schema.Resource {
 				Default:  "default",
 				ForceNew: true,
 			},
+			"encoding": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"segments": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
 
+var validateSMSTemplatePlaceholders = template_render.ValidateTemplateDiff(template_render.KindSMS, "message")
+
+func customizeSMSTemplateDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := validateSMSTemplatePlaceholders(ctx, d, meta); err != nil {
+		return err
+	}
+	message, ok, err := template_render.DiffText(d, "message")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		_ = d.SetNewComputed("encoding")
+		_ = d.SetNewComputed("segments")
+		return nil
+	}
+	// Placeholders are counted as written, the delivered message may need more segments
+	info := template_render.SMSSegments(message)
+	if maxSegments := d.Get("max_segments").(int); maxSegments > 0 && info.Segments > maxSegments {
+		return fmt.Errorf("message needs %d SMS segments (%d %s characters), which exceeds max_segments %d",
+			info.Segments, info.Length, info.Encoding, maxSegments)
+	}
+	if d.Get("encoding").(string) != info.Encoding {
+		if err := d.SetNew("encoding", info.Encoding); err != nil {
+			return err
+		}
+	}
+	if d.Get("segments").(int) != info.Segments {
+		if err := d.SetNew("segments", info.Segments); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func schemaWriteSMSTemplate(s iam.SMSTemplate, d *schema.ResourceData) error {
 	if err := d.Set("organization_id", s.Organization.Value); err != nil {
 		return err
@@ -61,6 +110,13 @@ func schemaWriteSMSTemplate(s iam.SMSTemplate, d *schema.ResourceData) error {
 	if err := d.Set("message", s.Message); err != nil {
 		return err
 	}
+	info := template_render.SMSSegments(s.Message)
+	if err := d.Set("encoding", info.Encoding); err != nil {
+		return err
+	}
+	if err := d.Set("segments", info.Segments); err != nil {
+		return err
+	}
 	if err := d.Set("locale", s.Locale); err != nil {
 		return err
 	}
@@ -179,3 +235,46 @@ func resourceIAMSMSTemplateCreate(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(createdTemplate.ID)
 	return resourceIAMSMSTemplateRead(ctx, d, meta)
 }
+
+func resourceIAMSMSTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var template *iam.SMSTemplate
+	var resp *iam.Response
+
+	c := meta.(*config.Config)
+
+	id := d.Id()
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
+		var err error
+		template, resp, err = client.SMSTemplates.GetSMSTemplateByID(id)
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading SMS template: %w", err))
+	}
+	if d.HasChange("message") {
+		// Just in time base64 encoding
+		template.Message = base64.StdEncoding.EncodeToString([]byte(d.Get("message").(string)))
+	}
+	if d.HasChange("external_id") {
+		template.ExternalID = d.Get("external_id").(string)
+	}
+	err = tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
+		var err error
+		_, resp, err = client.SMSTemplates.UpdateSMSTemplate(*template)
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating SMS template: %w", err))
+	}
+	return resourceIAMSMSTemplateRead(ctx, d, meta)
+}
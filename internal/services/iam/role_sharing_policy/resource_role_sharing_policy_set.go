@@ -0,0 +1,415 @@
+package role_sharing_policy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/organization"
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+func ResourceRoleSharingPolicySet() *schema.Resource {
+	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			StateContext: importRoleSharingPolicySet,
+		},
+		CreateContext: resourceRoleSharingPolicySetCreate,
+		ReadContext:   resourceRoleSharingPolicySetRead,
+		UpdateContext: resourceRoleSharingPolicySetUpdate,
+		DeleteContext: resourceRoleSharingPolicySetDelete,
+		CustomizeDiff: resourceRoleSharingPolicySetCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"sharing_policy": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Restricted", "AllowChildren", "Denied",
+				}, false),
+			},
+			"purpose": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"target_organization_ids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"target_organization_ids", "selector"},
+			},
+			"selector": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"parent_organization_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name_regex": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsValidRegExp,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"max_depth": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"exclude_organization_ids": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"selector_organization_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"policy_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"source_organization_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceRoleSharingPolicySetCustomizeDiff plans a reconcile when the shared organizations drifted from the desired ones
+func resourceRoleSharingPolicySetCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("selector") {
+		_ = d.SetNewComputed("selector_organization_ids")
+		return d.SetNewComputed("policy_ids")
+	}
+	desired := unionTargets(expandStringSet(d.Get("target_organization_ids")), expandStringSet(d.Get("selector_organization_ids")))
+	toAdd, toRemove := diffTargets(desired, policyTargets(d.Get("policy_ids")))
+	if len(toAdd) > 0 || len(toRemove) > 0 || d.HasChange("sharing_policy") || d.HasChange("purpose") {
+		return d.SetNewComputed("policy_ids")
+	}
+	return nil
+}
+
+func expandStringSet(raw interface{}) []string {
+	set, ok := raw.(*schema.Set)
+	if !ok || set == nil {
+		return nil
+	}
+	return tools.ExpandStringList(set.List())
+}
+
+func policyTargets(raw interface{}) []string {
+	var targets []string
+	for orgID := range raw.(map[string]interface{}) {
+		targets = append(targets, orgID)
+	}
+	return targets
+}
+
+// resolveSelector returns the organizations currently matching the selector
+func resolveSelector(ctx context.Context, client *iam.Client, selector *orgSelector) ([]string, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	nodes, err := organization.WalkOrgTree(ctx, client, selector.ParentOrganizationID, selector.MaxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("walking organizations below '%s': %w", selector.ParentOrganizationID, err)
+	}
+	return selector.selectOrgs(nodes)
+}
+
+func applySharingPolicy(ctx context.Context, client *iam.Client, roleID string, policy iam.RoleSharingPolicy) (*iam.RoleSharingPolicy, error) {
+	var applied *iam.RoleSharingPolicy
+	var resp *iam.Response
+
+	err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+		var err error
+		applied, resp, err = client.Roles.ApplySharingPolicy(iam.Role{ID: roleID}, policy)
+		if err != nil {
+			_ = client.TokenRefresh()
+		}
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sharing role with '%s': %w", policy.TargetOrganizationID, err)
+	}
+	return applied, nil
+}
+
+func removeSharingPolicy(ctx context.Context, client *iam.Client, roleID string, policy iam.RoleSharingPolicy) error {
+	var resp *iam.Response
+
+	err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+		var err error
+		_, resp, err = client.Roles.RemoveSharingPolicy(iam.Role{ID: roleID}, policy)
+		if err != nil {
+			_ = client.TokenRefresh()
+		}
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if resp != nil && resp.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("removing share with '%s': %w", policy.TargetOrganizationID, err)
+	}
+	return nil
+}
+
+// reconcileRoleSharingPolicySet shares the role with every desired organization and unshares it from the rest.
+// policy_ids always reflects what was done, also when reconciling stops halfway
+func reconcileRoleSharingPolicySet(ctx context.Context, client *iam.Client, d *schema.ResourceData, reapply bool) error {
+	roleID := d.Get("role_id").(string)
+	sharingPolicy := d.Get("sharing_policy").(string)
+	purpose := d.Get("purpose").(string)
+
+	selected, err := resolveSelector(ctx, client, expandOrgSelector(d.Get("selector")))
+	if err != nil {
+		return err
+	}
+	_ = d.Set("selector_organization_ids", selected)
+
+	// policy_ids is unknown in the plan when a reconcile is due, so start from the state
+	previous, _ := d.GetChange("policy_ids")
+	policyIDs := make(map[string]string)
+	for orgID, id := range previous.(map[string]interface{}) {
+		policyIDs[orgID] = id.(string)
+	}
+	defer func() {
+		_ = d.Set("policy_ids", policyIDs)
+	}()
+
+	desired := unionTargets(expandStringSet(d.Get("target_organization_ids")), selected)
+	current := policyTargets(previous)
+	toAdd, toRemove := diffTargets(desired, current)
+	if reapply {
+		toAdd = desired
+	}
+
+	oldPolicy, _ := d.GetChange("sharing_policy")
+	for _, orgID := range toRemove {
+		err := removeSharingPolicy(ctx, client, roleID, iam.RoleSharingPolicy{
+			TargetOrganizationID: orgID,
+			SharingPolicy:        oldPolicy.(string),
+		})
+		if err != nil {
+			return err
+		}
+		delete(policyIDs, orgID)
+	}
+	for _, orgID := range toAdd {
+		policy, err := applySharingPolicy(ctx, client, roleID, iam.RoleSharingPolicy{
+			SharingPolicy:        sharingPolicy,
+			TargetOrganizationID: orgID,
+			Purpose:              purpose,
+		})
+		if err != nil {
+			return err
+		}
+		policyIDs[orgID] = policy.InternalID
+	}
+	return nil
+}
+
+func resourceRoleSharingPolicySetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Set the ID up front so organizations shared before a failure are tracked in state
+	d.SetId(d.Get("role_id").(string))
+	if err := reconcileRoleSharingPolicySet(ctx, client, d, false); err != nil {
+		if len(d.Get("policy_ids").(map[string]interface{})) == 0 {
+			d.SetId("")
+		}
+		return diag.FromErr(err)
+	}
+	return resourceRoleSharingPolicySetRead(ctx, d, m)
+}
+
+func resourceRoleSharingPolicySetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	reapply := d.HasChange("sharing_policy") || d.HasChange("purpose")
+	if err := reconcileRoleSharingPolicySet(ctx, client, d, reapply); err != nil {
+		// policy_ids keeps what was done, while a policy or purpose change stays planned until it reached every organization
+		if reapply {
+			oldPolicy, _ := d.GetChange("sharing_policy")
+			oldPurpose, _ := d.GetChange("purpose")
+			_ = d.Set("sharing_policy", oldPolicy)
+			_ = d.Set("purpose", oldPurpose)
+		}
+		return diag.FromErr(err)
+	}
+	return resourceRoleSharingPolicySetRead(ctx, d, m)
+}
+
+func listSharingPolicies(ctx context.Context, client *iam.Client, roleID string) ([]iam.RoleSharingPolicy, *iam.Response, error) {
+	var policies *[]iam.RoleSharingPolicy
+	var resp *iam.Response
+
+	err := tools.TryHTTPCall(ctx, 8, func() (*http.Response, error) {
+		var err error
+		policies, resp, err = client.Roles.ListSharingPolicies(iam.Role{ID: roleID}, &iam.ListSharingPoliciesOptions{})
+		if err != nil {
+			_ = client.TokenRefresh()
+		}
+		if resp == nil {
+			return nil, err
+		}
+		return resp.Response, err
+	})
+	if err != nil || policies == nil {
+		return nil, resp, err
+	}
+	return *policies, resp, nil
+}
+
+// importRoleSharingPolicySet adopts all current shares of the role given by the import ID
+func importRoleSharingPolicySet(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return nil, err
+	}
+	policies, _, err := listSharingPolicies(ctx, client, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("listing sharing policies of role '%s': %w", d.Id(), err)
+	}
+	policyIDs := make(map[string]string)
+	var targets []string
+	for _, policy := range policies {
+		policyIDs[policy.TargetOrganizationID] = policy.InternalID
+		targets = append(targets, policy.TargetOrganizationID)
+		_ = d.Set("sharing_policy", policy.SharingPolicy)
+		_ = d.Set("purpose", policy.Purpose)
+	}
+	_ = d.Set("role_id", d.Id())
+	_ = d.Set("policy_ids", policyIDs)
+	_ = d.Set("target_organization_ids", targets)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRoleSharingPolicySetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roleID := d.Id()
+
+	policies, resp, err := listSharingPolicies(ctx, client, roleID)
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	shared := make(map[string]iam.RoleSharingPolicy)
+	for _, policy := range policies {
+		shared[policy.TargetOrganizationID] = policy
+	}
+	// Only track organizations this resource shared the role with; drop the ones unshared elsewhere
+	policyIDs := make(map[string]string)
+	for orgID := range d.Get("policy_ids").(map[string]interface{}) {
+		policy, ok := shared[orgID]
+		if !ok {
+			continue
+		}
+		policyIDs[orgID] = policy.InternalID
+		_ = d.Set("source_organization_id", policy.SourceOrganizationID)
+		_ = d.Set("role_name", policy.RoleName)
+	}
+	_ = d.Set("role_id", roleID)
+	_ = d.Set("policy_ids", policyIDs)
+
+	selected, err := resolveSelector(ctx, client, expandOrgSelector(d.Get("selector")))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "unable to resolve selector",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	_ = d.Set("selector_organization_ids", selected)
+	return diags
+}
+
+func resourceRoleSharingPolicySetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*config.Config)
+
+	client, err := c.IAMClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roleID := d.Get("role_id").(string)
+	sharingPolicy := d.Get("sharing_policy").(string)
+
+	policyIDs := d.Get("policy_ids").(map[string]interface{})
+	for _, orgID := range policyTargets(policyIDs) {
+		err := removeSharingPolicy(ctx, client, roleID, iam.RoleSharingPolicy{
+			TargetOrganizationID: orgID,
+			SharingPolicy:        sharingPolicy,
+		})
+		if err != nil {
+			_ = d.Set("policy_ids", policyIDs)
+			return diag.FromErr(err)
+		}
+		delete(policyIDs, orgID)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,114 @@
+package role_sharing_policy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/organization"
+)
+
+// orgSelector selects organizations below a parent organization
+type orgSelector struct {
+	ParentOrganizationID string
+	NameRegex            string
+	Type                 string
+	MaxDepth             int
+	Exclude              []string
+}
+
+func expandOrgSelector(raw interface{}) *orgSelector {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	var exclude []string
+	if set, ok := m["exclude_organization_ids"].(interface{ List() []interface{} }); ok {
+		for _, v := range set.List() {
+			exclude = append(exclude, v.(string))
+		}
+	}
+	return &orgSelector{
+		ParentOrganizationID: m["parent_organization_id"].(string),
+		NameRegex:            m["name_regex"].(string),
+		Type:                 m["type"].(string),
+		MaxDepth:             m["max_depth"].(int),
+		Exclude:              exclude,
+	}
+}
+
+// selectOrgs returns the sorted IDs of the nodes matching the selector
+func (s orgSelector) selectOrgs(nodes []organization.OrgNode) ([]string, error) {
+	var nameRegex *regexp.Regexp
+	if s.NameRegex != "" {
+		var err error
+		nameRegex, err = regexp.Compile(s.NameRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid name_regex: %w", err)
+		}
+	}
+	excluded := make(map[string]bool)
+	for _, id := range s.Exclude {
+		excluded[id] = true
+	}
+	var ids []string
+	for _, node := range nodes {
+		if excluded[node.ID] {
+			continue
+		}
+		if s.MaxDepth > 0 && node.Depth > s.MaxDepth {
+			continue
+		}
+		if s.Type != "" && !strings.EqualFold(s.Type, node.Type) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(node.Name) {
+			continue
+		}
+		ids = append(ids, node.ID)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// diffTargets returns the organizations to share with and to stop sharing with
+func diffTargets(desired, current []string) (toAdd, toRemove []string) {
+	inDesired := make(map[string]bool)
+	for _, id := range desired {
+		inDesired[id] = true
+	}
+	inCurrent := make(map[string]bool)
+	for _, id := range current {
+		inCurrent[id] = true
+		if !inDesired[id] {
+			toRemove = append(toRemove, id)
+		}
+	}
+	for id := range inDesired {
+		if !inCurrent[id] {
+			toAdd = append(toAdd, id)
+		}
+	}
+	sort.Strings(toAdd)
+	sort.Strings(toRemove)
+	return toAdd, toRemove
+}
+
+// unionTargets merges the explicit targets with the selected organizations
+func unionTargets(lists ...[]string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, list := range lists {
+		for _, id := range list {
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,47 @@
+package role_sharing_policy
+
+import (
+	"testing"
+
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/organization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectOrgs(t *testing.T) {
+	nodes := []organization.OrgNode{
+		{ID: "a", Name: "clinic-a", Type: "Hospital", Depth: 1},
+		{ID: "b", Name: "clinic-b", Type: "Lab", Depth: 1},
+		{ID: "c", Name: "other", Type: "Hospital", Depth: 1},
+		{ID: "d", Name: "clinic-d", Type: "Hospital", Depth: 2},
+	}
+
+	ids, err := orgSelector{MaxDepth: 0}.selectOrgs(nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids)
+
+	ids, err = orgSelector{MaxDepth: 1, NameRegex: "^clinic-"}.selectOrgs(nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, ids)
+
+	ids, err = orgSelector{Type: "hospital", Exclude: []string{"c"}}.selectOrgs(nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "d"}, ids)
+
+	_, err = orgSelector{NameRegex: "("}.selectOrgs(nodes)
+	assert.Error(t, err)
+}
+
+func TestDiffTargets(t *testing.T) {
+	toAdd, toRemove := diffTargets([]string{"a", "b", "c"}, []string{"c", "d"})
+	assert.Equal(t, []string{"a", "b"}, toAdd)
+	assert.Equal(t, []string{"d"}, toRemove)
+
+	toAdd, toRemove = diffTargets([]string{"a"}, []string{"a"})
+	assert.Empty(t, toAdd)
+	assert.Empty(t, toRemove)
+}
+
+func TestUnionTargets(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, unionTargets([]string{"c", "a"}, []string{"b", "a", ""}))
+	assert.Empty(t, unionTargets(nil, nil))
+}
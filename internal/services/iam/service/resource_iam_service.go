@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/philips-software/go-dip-api/iam"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+	"github.com/philips-software/terraform-provider-hsdp/internal/services/iam/importer"
 	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
 )
 
@@ -25,13 +26,14 @@ func ResourceIAMService() *schema.Resource {
 	return &schema.Resource{
 		Description: descriptions["service"],
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importer.StateContext("service"),
 		},
 		SchemaVersion: 6,
 		CreateContext: resourceIAMServiceCreate,
 		ReadContext:   resourceIAMServiceRead,
 		UpdateContext: resourceIAMServiceUpdate,
 		DeleteContext: resourceIAMServiceDelete,
+		CustomizeDiff: customizeServiceDiff,
 		StateUpgraders: []schema.StateUpgrader{
 			{
 				Type:    ResourceIAMServiceV3().CoreConfigSchema().ImpliedType(),
@@ -152,6 +154,67 @@ func ResourceIAMService() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Description: "Default scopes. You do not have to specify these explicitly when requesting a token.",
 			},
+			"rotation": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Enables in-place credential rotation. Generated keys are rotated automatically ahead of `expires_on`, self-managed keys and certificates are replaced when changed. With `keep_previous` the previous credentials keep working for `overlap` days.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rotate_before": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Rotate the credentials this many days before they expire.",
+						},
+						"keep_previous": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Keep the previous credentials working during the overlap window. IAM holds a single credential per service, so the new credentials are installed on a new service.",
+						},
+						"overlap": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      7,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Number of days the previous service and its credentials are kept after a rotation.",
+						},
+					},
+				},
+			},
+			"previous_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the service which was active before the last rotation.",
+			},
+			"previous_service_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The service ID of the service which was active before the last rotation.",
+			},
+			"previous_private_key": {
+				Type:        schema.TypeString,
+				Sensitive:   true,
+				Computed:    true,
+				Description: "The private key which was active before the last rotation.",
+			},
+			"previous_certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The self-managed certificate which was active before the last rotation.",
+			},
+			"previous_expires_on": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiration date of the credentials which were active before the last rotation.",
+			},
+			"rotated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time of the last credential rotation.",
+			},
 		},
 	}
 }
@@ -159,12 +222,22 @@ func ResourceIAMService() *schema.Resource {
 func resourceIAMServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*config.Config)
 
-	var diags diag.Diagnostics
-
 	client, err := c.IAMClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	createdService, diags := createService(ctx, client, d)
+	if len(diags) > 0 {
+		return diags
+	}
+	d.SetId(createdService.ID)
+	return resourceIAMServiceRead(ctx, d, m)
+}
+
+// createService creates a service from the configuration and installs its credentials, scopes and token
+// validity. The service is removed again when any of these steps fail
+func createService(ctx context.Context, client *iam.Client, d *schema.ResourceData) (*iam.Service, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
 	var s iam.Service
 	s.Description = d.Get("description").(string)
@@ -182,15 +255,15 @@ func resourceIAMServiceCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	if selfPrivateKey == "" && selfExpiresOn != "" {
-		return diag.FromErr(fmt.Errorf("you cannot set 'self_managed_expires_on' value without also specifying the 'self_managed_private_key'"))
+		return nil, diag.FromErr(fmt.Errorf("you cannot set 'self_managed_expires_on' value without also specifying the 'self_managed_private_key'"))
 	}
 	if selfCertificate != "" && selfExpiresOn != "" {
-		return diag.FromErr(fmt.Errorf("you cannot set 'self_managed_expires_on' value in combination with 'self_managed_certificate'"))
+		return nil, diag.FromErr(fmt.Errorf("you cannot set 'self_managed_expires_on' value in combination with 'self_managed_certificate'"))
 	}
 
 	var createdService *iam.Service
 
-	err = tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
+	err := tools.TryHTTPCall(ctx, 10, func() (*http.Response, error) {
 		var err error
 		var resp *iam.Response
 		createdService, resp, err = client.Services.CreateService(s)
@@ -203,7 +276,7 @@ func resourceIAMServiceCreate(ctx context.Context, d *schema.ResourceData, m int
 		return resp.Response, err
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, diag.FromErr(err)
 	}
 	if selfCertificate == "" { // Only set private key when not using self-managed certificate
 		_ = d.Set("private_key", iam.FixPEM(createdService.PrivateKey))
@@ -211,12 +284,12 @@ func resourceIAMServiceCreate(ctx context.Context, d *schema.ResourceData, m int
 	// Set certificate if set from the get-go
 	if selfPrivateKey != "" {
 		if selfCertificate != "" {
-			return diag.FromErr(fmt.Errorf("you cannot set 'self_managed_certificate' value in combination with 'self_managed_private_key'"))
+			return nil, diag.FromErr(fmt.Errorf("you cannot set 'self_managed_certificate' value in combination with 'self_managed_private_key'"))
 		}
 		diags = setSelfManagedPrivateKey(client, *createdService, d)
 		if len(diags) > 0 {
 			_, _, _ = client.Services.DeleteService(*createdService) // Cleanup
-			return diags
+			return nil, diags
 		}
 	}
 	// Set certificate if set from publicKey
@@ -224,7 +297,7 @@ func resourceIAMServiceCreate(ctx context.Context, d *schema.ResourceData, m int
 		diags = setSelfManagedCertificate(client, *createdService, selfCertificate)
 		if len(diags) > 0 {
 			_, _, _ = client.Services.DeleteService(*createdService) // Cleanup
-			return diags
+			return nil, diags
 		}
 	}
 
@@ -242,10 +315,9 @@ func resourceIAMServiceCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 	if len(diags) > 0 {
 		_, _, _ = client.Services.DeleteService(*createdService) // Cleanup
-		return diags
+		return nil, diags
 	}
-	d.SetId(createdService.ID)
-	return resourceIAMServiceRead(ctx, d, m)
+	return createdService, diags
 }
 
 func resourceIAMServiceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -275,6 +347,16 @@ func resourceIAMServiceRead(_ context.Context, d *schema.ResourceData, m interfa
 	_ = d.Set("scopes", s.Scopes)
 	_ = d.Set("expires_on", s.ExpiresOn)
 	_ = d.Set("default_scopes", s.DefaultScopes)
+
+	if policy := getRotationPolicy(d); policy != nil && usesSelfManagedCredentials(d) {
+		if due, _ := rotationDue(s.ExpiresOn, policy.RotateBefore, time.Now()); due {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("credentials of service '%s' expire on %s", s.Name, s.ExpiresOn),
+				Detail:   "Self-managed credentials cannot be rotated by the provider. Supply a new self_managed_certificate to rotate them in-place.",
+			})
+		}
+	}
 	return diags
 }
 
@@ -288,6 +370,19 @@ func resourceIAMServiceUpdate(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	policy := getRotationPolicy(d)
+	oldExpiresOn, _ := d.GetChange("expires_on")
+	rotateToNew := false
+	if policy != nil && policy.KeepPrevious {
+		if rotateToNew, err = credentialsRotating(d, policy, oldExpiresOn.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	// Only one previous service is kept, so a new rotation retires it regardless of the overlap
+	if diags := retirePreviousService(client, d, rotateToNew); len(diags) > 0 {
+		return diags
+	}
+
 	var s iam.Service
 	s.ID = d.Id()
 	s.ServiceID = d.Get("service_id").(string)
@@ -336,6 +431,22 @@ func resourceIAMServiceUpdate(ctx context.Context, d *schema.ResourceData, m int
 			_, _, _ = client.Services.AddScopes(s, []string{}, toAdd)
 		}
 	}
+	if rotateToNew {
+		if diags := rotateToNewService(ctx, client, d); len(diags) > 0 {
+			return diags
+		}
+		return resourceIAMServiceRead(ctx, d, m)
+	}
+
+	if d.HasChange("self_managed_private_key") || d.HasChange("self_managed_expires_on") {
+		if d.Get("self_managed_private_key").(string) != "" {
+			diags = setSelfManagedPrivateKey(client, s, d)
+			if len(diags) > 0 {
+				return diags
+			}
+			markRotated(d)
+		}
+	}
 	if d.HasChange("self_managed_certificate") ||
 		d.HasChange("self_managed_certificate_nonsensitive") {
 		_, newCertificate := d.GetChange("self_managed_certificate")
@@ -351,6 +462,21 @@ func resourceIAMServiceUpdate(ctx context.Context, d *schema.ResourceData, m int
 		if len(diags) > 0 {
 			return diags
 		}
+		markRotated(d)
+	}
+	if policy != nil && !usesSelfManagedCredentials(d) {
+		due, err := rotationDue(oldExpiresOn.(string), policy.RotateBefore, time.Now())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if due {
+			newPrivateKey, rotateDiags := rotateGeneratedKey(client, s, d.Get("validity").(int))
+			if len(rotateDiags) > 0 {
+				return rotateDiags
+			}
+			_ = d.Set("private_key", newPrivateKey)
+			markRotated(d)
+		}
 	}
 
 	return resourceIAMServiceRead(ctx, d, m)
@@ -366,6 +492,10 @@ func resourceIAMServiceDelete(_ context.Context, d *schema.ResourceData, m inter
 		return diag.FromErr(err)
 	}
 
+	if diags := retirePreviousService(client, d, true); len(diags) > 0 {
+		return diags
+	}
+
 	var s iam.Service
 	s.ID = d.Id()
 	ok, _, err := client.Services.DeleteService(s)
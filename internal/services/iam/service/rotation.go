@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/philips-software/terraform-provider-hsdp/internal/config"
+)
+
+const rotationKeyBits = 2048
+
+// previousKeys are the attributes describing the service kept from the last rotation
+var previousKeys = []string{"previous_id", "previous_service_id", "previous_private_key", "previous_certificate", "previous_expires_on"}
+
+type rotationPolicy struct {
+	RotateBefore int
+	KeepPrevious bool
+	Overlap      int
+}
+
+// overlapDays returns the number of days the previous service is kept. Without a policy it is retired on the
+// next apply
+func (p *rotationPolicy) overlapDays() int {
+	if p == nil {
+		return 0
+	}
+	return p.Overlap
+}
+
+func getRotationPolicy(d interface{ Get(string) interface{} }) *rotationPolicy {
+	list, ok := d.Get("rotation").([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	return &rotationPolicy{
+		RotateBefore: m["rotate_before"].(int),
+		KeepPrevious: m["keep_previous"].(bool),
+		Overlap:      m["overlap"].(int),
+	}
+}
+
+// rotationDue returns true when expiresOn falls within rotateBefore days of now
+func rotationDue(expiresOn string, rotateBefore int, now time.Time) (bool, error) {
+	if expiresOn == "" {
+		return false, nil
+	}
+	expires, err := time.Parse(time.RFC3339, expiresOn)
+	if err != nil {
+		return false, fmt.Errorf("parsing expires_on '%s': %w", expiresOn, err)
+	}
+	return !now.Before(expires.AddDate(0, 0, -rotateBefore)), nil
+}
+
+// previousRetired returns true when the overlap window after rotatedAt has passed
+func previousRetired(rotatedAt string, overlap int, now time.Time) bool {
+	if rotatedAt == "" {
+		return true
+	}
+	rotated, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return true
+	}
+	return !now.Before(rotated.AddDate(0, 0, overlap))
+}
+
+func usesSelfManagedCredentials(d interface{ Get(string) interface{} }) bool {
+	return d.Get("self_managed_private_key").(string) != "" ||
+		d.Get("self_managed_certificate").(string) != "" ||
+		d.Get("self_managed_certificate_nonsensitive").(string) != ""
+}
+
+// selfManagedCredentialsChanged returns true when a new self-managed key or certificate is configured
+func selfManagedCredentialsChanged(d interface {
+	Get(string) interface{}
+	HasChange(string) bool
+}) bool {
+	if (d.HasChange("self_managed_private_key") || d.HasChange("self_managed_expires_on")) &&
+		d.Get("self_managed_private_key").(string) != "" {
+		return true
+	}
+	return (d.HasChange("self_managed_certificate") || d.HasChange("self_managed_certificate_nonsensitive")) &&
+		(d.Get("self_managed_certificate").(string) != "" || d.Get("self_managed_certificate_nonsensitive").(string) != "")
+}
+
+// credentialsRotating returns true when the update replaces the credentials of the service
+func credentialsRotating(d *schema.ResourceData, policy *rotationPolicy, expiresOn string) (bool, error) {
+	if usesSelfManagedCredentials(d) {
+		return selfManagedCredentialsChanged(d), nil
+	}
+	return rotationDue(expiresOn, policy.RotateBefore, time.Now())
+}
+
+// customizeServiceDiff plans an in-place key rotation when the provider managed key is about to expire or
+// a new self-managed credential is configured, and the retirement of the previous service once the overlap
+// has passed
+func customizeServiceDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	policy := getRotationPolicy(d)
+	var keys []string
+	if d.Get("previous_id").(string) != "" && previousRetired(d.Get("rotated_at").(string), policy.overlapDays(), time.Now()) {
+		keys = append(keys, previousKeys...)
+	}
+	if policy != nil {
+		rotate := false
+		if usesSelfManagedCredentials(d) {
+			rotate = selfManagedCredentialsChanged(d)
+		} else {
+			due, err := rotationDue(d.Get("expires_on").(string), policy.RotateBefore, time.Now())
+			if err != nil {
+				return err
+			}
+			rotate = due
+		}
+		if rotate {
+			keys = append(keys, "private_key", "expires_on", "rotated_at")
+			if policy.KeepPrevious {
+				keys = append(keys, "service_id")
+				keys = append(keys, previousKeys...)
+			}
+		}
+	}
+	for _, key := range keys {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markRotated records the time of a rotation, once the new credentials were accepted by IAM
+func markRotated(d *schema.ResourceData) {
+	_ = d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+}
+
+// rotateToNewService creates a new service with the configured credentials and keeps the current service as
+// the previous one, so its credentials keep working until the overlap has passed
+func rotateToNewService(ctx context.Context, client *iam.Client, d *schema.ResourceData) diag.Diagnostics {
+	previousServiceID, _ := d.GetChange("service_id")
+	previousPrivateKey, _ := d.GetChange("private_key")
+	previousExpiresOn, _ := d.GetChange("expires_on")
+	previousCertificate, _ := d.GetChange("self_managed_certificate")
+	if certificate, _ := d.GetChange("self_managed_certificate_nonsensitive"); certificate.(string) != "" {
+		previousCertificate = certificate
+	}
+	previousID := d.Id()
+
+	created, diags := createService(ctx, client, d)
+	if len(diags) > 0 {
+		return diags
+	}
+	d.SetId(created.ID)
+	_ = d.Set("previous_id", previousID)
+	_ = d.Set("previous_service_id", previousServiceID)
+	_ = d.Set("previous_private_key", previousPrivateKey)
+	_ = d.Set("previous_certificate", previousCertificate)
+	_ = d.Set("previous_expires_on", previousExpiresOn)
+	markRotated(d)
+	return diags
+}
+
+// retirePreviousService deletes the service kept from the last rotation once the overlap has passed, or right
+// away when force is set
+func retirePreviousService(client *iam.Client, d *schema.ResourceData, force bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	previousID, _ := d.GetChange("previous_id")
+	if previousID.(string) == "" {
+		return diags
+	}
+	rotatedAt, _ := d.GetChange("rotated_at")
+	if !force && !previousRetired(rotatedAt.(string), getRotationPolicy(d).overlapDays(), time.Now()) {
+		return diags
+	}
+	ok, resp, err := client.Services.DeleteService(iam.Service{ID: previousID.(string)})
+	if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+		return diag.FromErr(fmt.Errorf("deleting previous service '%s': %w", previousID, err))
+	}
+	if err == nil && !ok {
+		return diag.FromErr(config.ErrDeleteServiceFailed)
+	}
+	for _, key := range previousKeys {
+		_ = d.Set(key, "")
+	}
+	return diags
+}
+
+// rotateGeneratedKey generates a fresh RSA key and installs it as the active service credential. The
+// previous key stops working immediately
+func rotateGeneratedKey(client *iam.Client, service iam.Service, validity int) (string, diag.Diagnostics) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rotationKeyBits)
+	if err != nil {
+		return "", diag.FromErr(fmt.Errorf("generating private key: %w", err))
+	}
+	expiresOn := time.Now().AddDate(0, validity, 0)
+	_, _, err = client.Services.UpdateServiceCertificate(service, privateKey, func(cert *x509.Certificate) error {
+		cert.NotAfter = expiresOn
+		return nil
+	})
+	if err != nil {
+		return "", diag.FromErr(fmt.Errorf("rotating private key: %w", err))
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	return string(keyPEM), nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotationDue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	due, err := rotationDue("2025-08-01T00:00:00Z", 30, now)
+	assert.Nil(t, err)
+	assert.False(t, due)
+
+	due, err = rotationDue("2025-06-20T00:00:00Z", 30, now)
+	assert.Nil(t, err)
+	assert.True(t, due)
+
+	due, err = rotationDue("2025-05-01T00:00:00Z", 30, now)
+	assert.Nil(t, err)
+	assert.True(t, due, "expired credentials should be rotated")
+
+	due, err = rotationDue("", 30, now)
+	assert.Nil(t, err)
+	assert.False(t, due)
+
+	_, err = rotationDue("tomorrow", 30, now)
+	assert.NotNil(t, err)
+}
+
+func TestPreviousRetired(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, previousRetired("2025-06-05T12:00:00Z", 7, now))
+	assert.True(t, previousRetired("2025-06-01T12:00:00Z", 7, now))
+	assert.True(t, previousRetired("2025-06-10T11:00:00Z", 0, now))
+	assert.True(t, previousRetired("", 7, now))
+
+	var policy *rotationPolicy
+	assert.Equal(t, 0, policy.overlapDays())
+}
@@ -0,0 +1,147 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+)
+
+const (
+	defaultTwilioBaseURL = "https://api.twilio.com"
+	twilioAPIVersion     = "2010-04-01"
+	smsVerifyTimeout     = 30 * time.Second
+)
+
+// twilioBaseURL derives the API base URL from the configured gateway endpoint
+func twilioBaseURL(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return defaultTwilioBaseURL
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
+
+type smsGatewayVerifier struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newSMSGatewayVerifier(endpoint string) *smsGatewayVerifier {
+	return &smsGatewayVerifier{
+		baseURL:    twilioBaseURL(endpoint),
+		httpClient: &http.Client{Timeout: smsVerifyTimeout},
+	}
+}
+
+func (v *smsGatewayVerifier) do(ctx context.Context, method, path string, form url.Values, gw iam.SMSGateway) (*http.Response, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, v.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(gw.Properties.SID, gw.Credentials.Token)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("Accept", "application/json")
+	return v.httpClient.Do(req)
+}
+
+// checkCredentials verifies the sid and token are accepted and that from_number belongs to the account
+func (v *smsGatewayVerifier) checkCredentials(ctx context.Context, gw iam.SMSGateway) error {
+	query := url.Values{}
+	query.Set("PhoneNumber", gw.Properties.FromNumber)
+	path := fmt.Sprintf("/%s/Accounts/%s/IncomingPhoneNumbers.json?%s",
+		twilioAPIVersion, url.PathEscape(gw.Properties.SID), query.Encode())
+	resp, err := v.do(ctx, http.MethodGet, path, nil, gw)
+	if err != nil {
+		return fmt.Errorf("contacting SMS provider: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("SMS provider rejected the credentials for sid '%s'", gw.Properties.SID)
+	default:
+		return fmt.Errorf("SMS provider returned unexpected status %d", resp.StatusCode)
+	}
+	var numbers struct {
+		IncomingPhoneNumbers []struct {
+			PhoneNumber string `json:"phone_number"`
+		} `json:"incoming_phone_numbers"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&numbers); err != nil {
+		return fmt.Errorf("decoding SMS provider response: %w", err)
+	}
+	if len(numbers.IncomingPhoneNumbers) == 0 {
+		return fmt.Errorf("from_number '%s' is not registered with sid '%s'", gw.Properties.FromNumber, gw.Properties.SID)
+	}
+	return nil
+}
+
+// sendTestMessage sends a test message to the given number through the configured account
+func (v *smsGatewayVerifier) sendTestMessage(ctx context.Context, gw iam.SMSGateway, to, message string) error {
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", gw.Properties.FromNumber)
+	form.Set("Body", message)
+	path := fmt.Sprintf("/%s/Accounts/%s/Messages.json", twilioAPIVersion, url.PathEscape(gw.Properties.SID))
+	resp, err := v.do(ctx, http.MethodPost, path, form, gw)
+	if err != nil {
+		return fmt.Errorf("contacting SMS provider: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var providerError struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&providerError)
+		return fmt.Errorf("sending test message to '%s' failed with status %d: %s", to, resp.StatusCode, providerError.Message)
+	}
+	return nil
+}
+
+// verifySMSGateway runs the checks configured in the verify block against the gateway provider
+func verifySMSGateway(ctx context.Context, d *schema.ResourceData, gw iam.SMSGateway) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	v, ok := d.GetOk("verify")
+	if !ok {
+		return diags
+	}
+	vL := v.([]interface{})
+	if len(vL) == 0 || vL[0] == nil {
+		return diags
+	}
+	settings := vL[0].(map[string]interface{})
+	if !strings.EqualFold(gw.Provider, "twilio") {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("verification is not supported for gateway provider '%s'", gw.Provider),
+		})
+	}
+	verifier := newSMSGatewayVerifier(gw.Properties.Endpoint)
+	if settings["check_credentials"].(bool) {
+		if err := verifier.checkCredentials(ctx, gw); err != nil {
+			return diag.FromErr(fmt.Errorf("SMS gateway verification: %w", err))
+		}
+	}
+	if to := settings["test_number"].(string); to != "" {
+		if err := verifier.sendTestMessage(ctx, gw, to, settings["test_message"].(string)); err != nil {
+			return diag.FromErr(fmt.Errorf("SMS gateway verification: %w", err))
+		}
+	}
+	return diags
+}
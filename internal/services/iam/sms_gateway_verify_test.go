@@ -0,0 +1,63 @@
+package iam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/philips-software/go-dip-api/iam"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwilioBaseURL(t *testing.T) {
+	assert.Equal(t, "https://api.twilio.com", twilioBaseURL("https://api.twilio.com/2010-04-01/Accounts"))
+	assert.Equal(t, "http://localhost:8080", twilioBaseURL("http://localhost:8080/foo"))
+	assert.Equal(t, defaultTwilioBaseURL, twilioBaseURL("not a url"))
+}
+
+func TestSMSGatewayVerifier(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2010-04-01/Accounts/AC123/IncomingPhoneNumbers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, token, _ := r.BasicAuth()
+		if token != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("PhoneNumber") != "+15550001" {
+			_, _ = w.Write([]byte(`{"incoming_phone_numbers":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"incoming_phone_numbers":[{"phone_number":"+15550001"}]}`))
+	})
+	mux.HandleFunc("/2010-04-01/Accounts/AC123/Messages.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("To") == "" || r.Form.Get("From") != "+15550001" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid number"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	verifier := newSMSGatewayVerifier(server.URL)
+	gw := iam.SMSGateway{
+		Properties:  iam.ProviderProperties{SID: "AC123", FromNumber: "+15550001"},
+		Credentials: iam.ProviderCredentials{Token: "good"},
+	}
+	ctx := context.Background()
+
+	assert.Nil(t, verifier.checkCredentials(ctx, gw))
+	assert.Nil(t, verifier.sendTestMessage(ctx, gw, "+15550002", "test"))
+
+	wrongNumber := gw
+	wrongNumber.Properties.FromNumber = "+15559999"
+	assert.NotNil(t, verifier.checkCredentials(ctx, wrongNumber))
+	assert.NotNil(t, verifier.sendTestMessage(ctx, wrongNumber, "+15550002", "test"))
+
+	wrongToken := gw
+	wrongToken.Credentials.Token = "bad"
+	assert.NotNil(t, verifier.checkCredentials(ctx, wrongToken))
+}
@@ -0,0 +1,62 @@
+package template_render
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DiffText returns the planned text of a message field. Fields ending in _base64
+// are decoded. The second return value is false when the value is not known yet
+// or when a _base64 field is not set.
+func DiffText(d *schema.ResourceDiff, field string) (string, bool, error) {
+	if !d.NewValueKnown(field) {
+		return "", false, nil
+	}
+	text := d.Get(field).(string)
+	if !strings.HasSuffix(field, "_base64") {
+		return text, true, nil
+	}
+	if text == "" {
+		return "", false, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(text)
+	if err != nil {
+		return "", false, fmt.Errorf("%s is not valid base64: %w", field, err)
+	}
+	return string(decoded), true, nil
+}
+
+// ValidateTemplateDiff returns a CustomizeDiffFunc which validates the placeholders
+// of the given message fields against the template type at plan time.
+// Email templates with format HTML are also checked for well-formedness.
+func ValidateTemplateDiff(kind string, fields ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if !d.NewValueKnown("type") {
+			return nil
+		}
+		templateType := d.Get("type").(string)
+		for _, field := range fields {
+			text, ok, err := DiffText(d, field)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if err := ValidatePlaceholders(kind, templateType, field, text); err != nil {
+				return err
+			}
+			if kind == KindEmail && strings.TrimSuffix(field, "_base64") == "message" && d.NewValueKnown("format") &&
+				strings.EqualFold(d.Get("format").(string), "HTML") {
+				if err := CheckHTML(text); err != nil {
+					return fmt.Errorf("%s is not well-formed HTML: %w", field, err)
+				}
+			}
+		}
+		return nil
+	}
+}
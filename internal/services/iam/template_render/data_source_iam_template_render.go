@@ -0,0 +1,158 @@
+package template_render
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DataSourceIAMTemplateRender() *schema.Resource {
+	return &schema.Resource{
+		Description: "Validates and renders an IAM email or SMS template locally using sample values.",
+		ReadContext: dataSourceIAMTemplateRenderRead,
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{KindEmail, KindSMS}, false),
+				Description:  "The template kind. Either 'email' or 'sms'.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The IAM template type e.g. 'PASSWORD_RECOVERY'.",
+			},
+			"format": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "HTML",
+				Description: "The email template format. HTML messages are checked for well-formedness.",
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The email subject line.",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The message body.",
+			},
+			"values": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Sample values keyed by placeholder name e.g. 'user.givenName'.",
+			},
+			"strict": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Fail when the template has validation errors. When false the errors are only exported.",
+			},
+			"rendered_subject": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rendered_message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"placeholders": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"missing_values": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"errors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"sms_encoding": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sms_length": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"sms_segments": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceIAMTemplateRenderRead(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	kind := d.Get("kind").(string)
+	templateType := d.Get("type").(string)
+	subject := d.Get("subject").(string)
+	message := d.Get("message").(string)
+	values := map[string]string{}
+	for k, v := range d.Get("values").(map[string]interface{}) {
+		values[k] = v.(string)
+	}
+
+	var errs []string
+	if err := ValidatePlaceholders(kind, templateType, "subject", subject); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if err := ValidatePlaceholders(kind, templateType, "message", message); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if kind == KindEmail && strings.EqualFold(d.Get("format").(string), "HTML") {
+		if err := CheckHTML(message); err != nil {
+			errs = append(errs, fmt.Sprintf("message is not well-formed HTML: %v", err))
+		}
+	}
+
+	renderedSubject, missingSubject := Render(subject, values)
+	renderedMessage, missing := Render(message, values)
+	for _, m := range missingSubject {
+		found := false
+		for _, n := range missing {
+			found = found || m == n
+		}
+		if !found {
+			missing = append(missing, m)
+		}
+	}
+
+	if kind == KindSMS {
+		info := SMSSegments(renderedMessage)
+		_ = d.Set("sms_encoding", info.Encoding)
+		_ = d.Set("sms_length", info.Length)
+		_ = d.Set("sms_segments", info.Segments)
+	}
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(kind+templateType+subject+message))))
+	_ = d.Set("rendered_subject", renderedSubject)
+	_ = d.Set("rendered_message", renderedMessage)
+	_ = d.Set("placeholders", Placeholders(subject+message))
+	_ = d.Set("missing_values", missing)
+	_ = d.Set("errors", errs)
+
+	if d.Get("strict").(bool) {
+		for _, e := range errs {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("invalid %s template '%s'", kind, templateType),
+				Detail:   e,
+			})
+		}
+	}
+	return diags
+}
@@ -0,0 +1,221 @@
+package template_render
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const (
+	KindEmail = "email"
+	KindSMS   = "sms"
+)
+
+var placeholderRegex = regexp.MustCompile(`{{\s*([A-Za-z0-9_.]+)\s*}}`)
+
+var commonEmailPlaceholders = []string{
+	"user.email",
+	"user.userName",
+	"user.givenName",
+	"user.familyName",
+}
+
+var commonSMSPlaceholders = []string{
+	"user.userName",
+	"user.givenName",
+	"user.familyName",
+	"user.displayName",
+}
+
+var otpSMSPlaceholders = []string{
+	"template.otp",
+	"template.otpExpiryPeriod",
+	"template.phoneNumber",
+}
+
+// emailPlaceholders lists the type specific placeholders per email template type.
+// Types which are not listed here are not validated.
+var emailPlaceholders = map[string][]string{
+	"ACCOUNT_ALREADY_VERIFIED":    {},
+	"ACCOUNT_UNLOCKED":            {},
+	"ACCOUNT_VERIFICATION":        {"link.verification", "template.linkExpiryPeriod"},
+	"EMAIL_VERIFICATION_VIA_CODE": {"OTP", "template.linkExpiryPeriod"},
+	"MFA_DISABLED":                {},
+	"MFA_ENABLED":                 {},
+	"MFA_OTP":                     {"template.otp", "template.otpExpiryPeriod"},
+	"PASSWORD_CHANGED":            {},
+	"PASSWORD_EXPIRY":             {"link.passwordChange", "password.expiresAfterPeriod"},
+	"PASSWORD_FAILED_ATTEMPTS":    {"user.lockoutPeriod"},
+	"PASSWORD_RECOVERY":           {"link.passwordReset"},
+}
+
+// smsPlaceholders lists the type specific placeholders per SMS template type
+var smsPlaceholders = map[string][]string{
+	"PHONE_VERIFICATION":       otpSMSPlaceholders,
+	"PASSWORD_RECOVERY":        otpSMSPlaceholders,
+	"PASSWORD_FAILED_ATTEMPTS": {"user.lockoutPeriod"},
+	"MFA_OTP":                  {"template.otp", "template.otpExpiryPeriod"},
+}
+
+// AllowedPlaceholders returns the placeholders IAM supports for the given
+// template kind and type. The second return value is false for unknown types.
+func AllowedPlaceholders(kind, templateType string) ([]string, bool) {
+	var common []string
+	var specific []string
+	var ok bool
+	switch kind {
+	case KindEmail:
+		common = commonEmailPlaceholders
+		specific, ok = emailPlaceholders[templateType]
+	case KindSMS:
+		common = commonSMSPlaceholders
+		specific, ok = smsPlaceholders[templateType]
+	}
+	if !ok {
+		return nil, false
+	}
+	allowed := make([]string, 0, len(common)+len(specific))
+	allowed = append(allowed, common...)
+	allowed = append(allowed, specific...)
+	return allowed, true
+}
+
+// Placeholders returns the unique placeholders used in text, in order of appearance
+func Placeholders(text string) []string {
+	var found []string
+	seen := map[string]bool{}
+	for _, match := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+		if seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		found = append(found, match[1])
+	}
+	return found
+}
+
+// UnknownPlaceholders returns the placeholders in text which are not in allowed
+func UnknownPlaceholders(text string, allowed []string) []string {
+	var unknown []string
+	for _, p := range Placeholders(text) {
+		known := false
+		for _, a := range allowed {
+			if p == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			unknown = append(unknown, p)
+		}
+	}
+	return unknown
+}
+
+// ValidatePlaceholders returns an error when text contains placeholders which
+// are not supported for the template kind and type
+func ValidatePlaceholders(kind, templateType, field, text string) error {
+	allowed, ok := AllowedPlaceholders(kind, templateType)
+	if !ok {
+		return nil
+	}
+	unknown := UnknownPlaceholders(text, allowed)
+	if len(unknown) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s contains placeholder(s) not supported by %s template type '%s': {{%s}}. Supported: {{%s}}",
+		field, kind, templateType, strings.Join(unknown, "}}, {{"), strings.Join(allowed, "}}, {{"))
+}
+
+// Render replaces the placeholders in text with values. Placeholders without a
+// value are left untouched and returned as missing.
+func Render(text string, values map[string]string) (string, []string) {
+	missingSet := map[string]bool{}
+	rendered := placeholderRegex.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholderRegex.FindStringSubmatch(match)[1]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		missingSet[name] = true
+		return match
+	})
+	missing := make([]string, 0, len(missingSet))
+	for name := range missingSet {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return rendered, missing
+}
+
+// optionalCloseElements may be closed implicitly according to the HTML spec
+var optionalCloseElements = map[string]bool{
+	"body": true, "colgroup": true, "dd": true, "dt": true, "head": true, "html": true, "li": true,
+	"option": true, "p": true, "tbody": true, "td": true, "tfoot": true, "th": true, "thead": true, "tr": true,
+}
+
+// htmlElements lists the non-void HTML elements CheckHTML tracks. Other tags, such as
+// an address written as <user@example.com>, are not HTML elements and are ignored
+var htmlElements = map[string]bool{
+	"a": true, "abbr": true, "address": true, "article": true, "aside": true, "b": true, "bdi": true,
+	"bdo": true, "blockquote": true, "body": true, "button": true, "caption": true, "center": true,
+	"cite": true, "code": true, "colgroup": true, "dd": true, "del": true, "details": true, "dfn": true,
+	"div": true, "dl": true, "dt": true, "em": true, "fieldset": true, "figcaption": true, "figure": true,
+	"font": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "head": true, "header": true, "html": true, "i": true, "ins": true, "kbd": true,
+	"label": true, "legend": true, "li": true, "main": true, "mark": true, "nav": true, "ol": true,
+	"option": true, "p": true, "pre": true, "q": true, "s": true, "samp": true, "section": true,
+	"select": true, "small": true, "span": true, "strike": true, "strong": true, "style": true,
+	"sub": true, "summary": true, "sup": true, "table": true, "tbody": true, "td": true, "textarea": true,
+	"tfoot": true, "th": true, "thead": true, "time": true, "title": true, "tr": true, "tt": true,
+	"u": true, "ul": true, "var": true,
+}
+
+// CheckHTML verifies that every HTML element in text is properly nested and closed.
+// Tags which are not HTML elements are ignored
+func CheckHTML(text string) error {
+	var stack []string
+	z := html.NewTokenizer(strings.NewReader(text))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() != io.EOF {
+				return z.Err()
+			}
+			for i := len(stack) - 1; i >= 0; i-- {
+				if !optionalCloseElements[stack[i]] {
+					return fmt.Errorf("element <%s> is never closed", stack[i])
+				}
+			}
+			return nil
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if htmlElements[string(name)] {
+				stack = append(stack, string(name))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if !htmlElements[tag] {
+				continue
+			}
+			for {
+				if len(stack) == 0 {
+					return fmt.Errorf("unexpected closing tag </%s>", tag)
+				}
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if top == tag {
+					break
+				}
+				if !optionalCloseElements[top] {
+					return fmt.Errorf("closing tag </%s> does not match open element <%s>", tag, top)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package template_render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePlaceholders(t *testing.T) {
+	assert.Nil(t, ValidatePlaceholders(KindEmail, "PASSWORD_RECOVERY", "message", "Hi {{user.givenName}}, reset here: {{ link.passwordReset }}"))
+	assert.NotNil(t, ValidatePlaceholders(KindEmail, "PASSWORD_RECOVERY", "message", "Hi {{user.givenName}}, verify here: {{link.verification}}"))
+	assert.NotNil(t, ValidatePlaceholders(KindEmail, "PASSWORD_CHANGED", "subject", "Hi {{user.givenNmae}}"))
+	assert.Nil(t, ValidatePlaceholders(KindEmail, "MFA_OTP", "message", "Your code is {{template.otp}}"))
+	assert.Nil(t, ValidatePlaceholders(KindEmail, "SOME_FUTURE_TYPE", "message", "{{anything.goes}}"))
+	assert.Nil(t, ValidatePlaceholders(KindSMS, "MFA_OTP", "message", "Your code is {{template.otp}}"))
+	assert.NotNil(t, ValidatePlaceholders(KindSMS, "MFA_OTP", "message", "Your code is {{template.phoneNumber}}"))
+	assert.NotNil(t, ValidatePlaceholders(KindSMS, "MFA_OTP", "message", "Hi {{user.email}}"))
+}
+
+func TestRender(t *testing.T) {
+	rendered, missing := Render("Hi {{user.givenName}}, code {{template.otp}} ({{ template.otp }})", map[string]string{
+		"template.otp": "123456",
+	})
+	assert.Equal(t, "Hi {{user.givenName}}, code 123456 (123456)", rendered)
+	assert.Equal(t, []string{"user.givenName"}, missing)
+}
+
+func TestCheckHTML(t *testing.T) {
+	assert.Nil(t, CheckHTML("Dear {{user.givenName}},\n\nPlain text is fine"))
+	assert.Nil(t, CheckHTML(`<html><body><p>Hi<br>there<p>Click <a href="{{link.passwordReset}}">here</a></body></html>`))
+	assert.Nil(t, CheckHTML(`<ul><li>one<li>two</ul><img src="logo.png"/>`))
+	assert.Nil(t, CheckHTML(`<p>Contact <user@example.com> or <support></p>`))
+	assert.NotNil(t, CheckHTML(`<div><b>bold</div>`))
+	assert.NotNil(t, CheckHTML(`<div>unclosed`))
+	assert.NotNil(t, CheckHTML(`text</span>`))
+}
+
+func TestSMSSegments(t *testing.T) {
+	assert.Equal(t, SMSInfo{Encoding: EncodingGSM7, Length: 0, Segments: 0}, SMSSegments(""))
+	assert.Equal(t, SMSInfo{Encoding: EncodingGSM7, Length: 5, Segments: 1}, SMSSegments("Hello"))
+	assert.Equal(t, SMSInfo{Encoding: EncodingGSM7, Length: 2, Segments: 1}, SMSSegments("€"))
+
+	long := make([]byte, 161)
+	for i := range long {
+		long[i] = 'a'
+	}
+	assert.Equal(t, SMSInfo{Encoding: EncodingGSM7, Length: 161, Segments: 2}, SMSSegments(string(long)))
+	assert.Equal(t, SMSInfo{Encoding: EncodingUCS2, Length: 7, Segments: 1}, SMSSegments("Hallo 你"))
+}
@@ -0,0 +1,65 @@
+package template_render
+
+const (
+	EncodingGSM7 = "GSM-7"
+	EncodingUCS2 = "UCS-2"
+)
+
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+const gsm7Extension = "^{}\\[~]|€\f"
+
+var gsm7BasicSet, gsm7ExtensionSet = runeSet(gsm7Basic), runeSet(gsm7Extension)
+
+func runeSet(s string) map[rune]bool {
+	set := map[rune]bool{}
+	for _, r := range s {
+		set[r] = true
+	}
+	return set
+}
+
+// SMSInfo describes how a message is sent over SMS
+type SMSInfo struct {
+	Encoding string
+	Length   int
+	Segments int
+}
+
+// SMSSegments calculates the encoding, length in encoding units and the number
+// of SMS segments needed to deliver message
+func SMSSegments(message string) SMSInfo {
+	gsm := true
+	gsmLength := 0
+	ucsLength := 0
+	for _, r := range message {
+		switch {
+		case gsm7BasicSet[r]:
+			gsmLength++
+		case gsm7ExtensionSet[r]:
+			gsmLength += 2
+		default:
+			gsm = false
+		}
+		if r > 0xFFFF {
+			ucsLength += 2 // Surrogate pair
+		} else {
+			ucsLength++
+		}
+	}
+	if gsm {
+		return SMSInfo{Encoding: EncodingGSM7, Length: gsmLength, Segments: segments(gsmLength, 160, 153)}
+	}
+	return SMSInfo{Encoding: EncodingUCS2, Length: ucsLength, Segments: segments(ucsLength, 70, 67)}
+}
+
+func segments(length, single, multi int) int {
+	if length == 0 {
+		return 0
+	}
+	if length <= single {
+		return 1
+	}
+	return (length + multi - 1) / multi
+}
@@ -0,0 +1,119 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+	tokenExchangeTimeout   = 30 * time.Second
+)
+
+// tokenExchangeRequest is an RFC 8693 token exchange against the IAM token endpoint
+type tokenExchangeRequest struct {
+	SubjectToken       string
+	RequestedSubject   string
+	Audience           string
+	Scopes             []string
+	RequestedTokenType string
+}
+
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IDToken         string `json:"id_token"`
+	TokenType       string `json:"token_type"`
+	IssuedTokenType string `json:"issued_token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	Scope           string `json:"scope"`
+	Error           string `json:"error"`
+	ErrorDesc       string `json:"error_description"`
+}
+
+type tokenExchanger struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	httpClient   *http.Client
+}
+
+func newTokenExchanger(baseIAMURL *url.URL, clientID, clientSecret string) *tokenExchanger {
+	return &tokenExchanger{
+		tokenURL:     strings.TrimSuffix(baseIAMURL.String(), "/") + "/authorize/oauth2/token",
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		httpClient:   &http.Client{Timeout: tokenExchangeTimeout},
+	}
+}
+
+// exchange swaps the subject token for a token of another subject, audience or scope
+func (e *tokenExchanger) exchange(ctx context.Context, r tokenExchangeRequest) (*tokenExchangeResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", grantTypeTokenExchange)
+	form.Set("subject_token", r.SubjectToken)
+	form.Set("subject_token_type", tokenTypeAccessToken)
+	if r.RequestedTokenType != "" {
+		form.Set("requested_token_type", r.RequestedTokenType)
+	}
+	if r.RequestedSubject != "" {
+		form.Set("requested_subject", r.RequestedSubject)
+	}
+	if r.Audience != "" {
+		form.Set("audience", r.Audience)
+	}
+	if len(r.Scopes) > 0 {
+		form.Set("scope", strings.Join(r.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(e.clientID, e.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Api-Version", "2")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token exchange: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("token exchange: reading response: %w", err)
+	}
+	var result tokenExchangeResponse
+	if err := json.Unmarshal(body, &result); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("token exchange: decoding response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if result.Error != "" {
+			return nil, fmt.Errorf("token exchange failed with status %d: %s %s", resp.StatusCode, result.Error, result.ErrorDesc)
+		}
+		return nil, fmt.Errorf("token exchange failed with status %d", resp.StatusCode)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange: response contains no access_token")
+	}
+	return &result, nil
+}
+
+// expiresAt converts a relative expires_in to a unix timestamp
+func expiresAt(now time.Time, expiresIn int64) int64 {
+	if expiresIn <= 0 {
+		return 0
+	}
+	return now.Add(time.Duration(expiresIn) * time.Second).Unix()
+}
+
+// splitScopes splits a space separated scope string
+func splitScopes(scope string) []string {
+	return strings.Fields(scope)
+}
@@ -0,0 +1,64 @@
+package iam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/authorize/oauth2/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		clientID, _, _ := r.BasicAuth()
+		_ = r.ParseForm()
+		if clientID != "client" || r.Form.Get("grant_type") != grantTypeTokenExchange {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"bad client"}`))
+			return
+		}
+		if r.Form.Get("subject_token") != "subject" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"exchanged","token_type":"Bearer","expires_in":600,"scope":"` +
+			r.Form.Get("scope") + `"}`))
+	}))
+	defer server.Close()
+
+	base, _ := url.Parse(server.URL + "/")
+	e := newTokenExchanger(base, "client", "secret")
+	assert.Equal(t, server.URL+"/authorize/oauth2/token", e.tokenURL)
+
+	result, err := e.exchange(context.Background(), tokenExchangeRequest{
+		SubjectToken: "subject",
+		Scopes:       []string{"openid", "mail"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "exchanged", result.AccessToken)
+	assert.Equal(t, int64(600), result.ExpiresIn)
+	assert.Equal(t, []string{"openid", "mail"}, splitScopes(result.Scope))
+
+	_, err = e.exchange(context.Background(), tokenExchangeRequest{SubjectToken: "other"})
+	assert.ErrorContains(t, err, "invalid_grant")
+
+	e.clientID = "wrong"
+	_, err = e.exchange(context.Background(), tokenExchangeRequest{SubjectToken: "subject"})
+	assert.ErrorContains(t, err, "bad client")
+}
+
+func TestExpiresAt(t *testing.T) {
+	now := time.Unix(1000, 0)
+	assert.Equal(t, int64(1600), expiresAt(now, 600))
+	assert.Equal(t, int64(0), expiresAt(now, 0))
+}
@@ -2,14 +2,16 @@ package user
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/philips-software/go-dip-api/iam"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/philips-software/terraform-provider-hsdp/internal/config"
 )
 
+const usersPageSize = 100
+
 func DataSourceIAMUsers() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceIAMUsersRead,
@@ -18,6 +20,18 @@ func DataSourceIAMUsers() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"login_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"email_prefix": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"email_verified": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -26,6 +40,25 @@ func DataSourceIAMUsers() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"last_login_older_than_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"truncated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 			"ids": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -41,12 +74,70 @@ func DataSourceIAMUsers() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"login": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"first_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email_verified": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"locked": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"last_login_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"group_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 	}
 
 }
 
-func dataSourceIAMUsersRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// optionalBool returns nil when key is not set in the configuration
+func optionalBool(d *schema.ResourceData, key string) *bool {
+	if d.GetRawConfig().GetAttr(key).IsNull() {
+		return nil
+	}
+	b := d.Get(key).(bool)
+	return &b
+}
+
+func dataSourceIAMUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*config.Config)
 
 	var diags diag.Diagnostics
@@ -55,57 +146,59 @@ func dataSourceIAMUsersRead(_ context.Context, d *schema.ResourceData, meta inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	token, err := client.Token()
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	orgID := d.Get("organization_id").(string)
-	var emailVerified *bool
-	var disabled *bool
-	if val, ok := d.GetOk("disabled"); ok {
-		b := val.(bool)
-		disabled = &b
-	}
-	if val, ok := d.GetOk("email_verified"); ok {
-		b := val.(bool)
-		emailVerified = &b
+	filter := userFilter{
+		OrganizationID:     orgID,
+		GroupID:            d.Get("group_id").(string),
+		LoginPrefix:        d.Get("login_prefix").(string),
+		EmailPrefix:        d.Get("email_prefix").(string),
+		EmailVerified:      optionalBool(d, "email_verified"),
+		Disabled:           optionalBool(d, "disabled"),
+		Locked:             optionalBool(d, "locked"),
+		LastLoginOlderThan: d.Get("last_login_older_than_days").(int),
 	}
 
-	profileType := "all"
-
-	userList, _, err := client.Users.GetAllUsers(&iam.GetUserOptions{
-		OrganizationID: &orgID,
-		ProfileType:    &profileType,
-	})
+	found, truncated, err := newUserSearcher(client.BaseIDMURL(), token, client.HttpClient()).search(ctx, filter.scimFilter(time.Now()),
+		usersPageSize, d.Get("max_results").(int))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var ids []string
-	var logins []string
-	var emailAddresses []string
-
-	for _, guid := range userList {
-		user, _, err := client.Users.GetUserByID(guid)
-		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("skipped user '%s' due to error", guid),
-				Detail:   err.Error(),
-			})
-			continue
-		}
-		if emailVerified != nil && *emailVerified != user.AccountStatus.EmailVerified {
-			continue
-		}
-		if disabled != nil && *disabled != user.AccountStatus.Disabled {
-			continue
+	ids := make([]string, 0, len(found))
+	logins := make([]string, 0, len(found))
+	emailAddresses := make([]string, 0, len(found))
+	users := make([]map[string]interface{}, 0, len(found))
+	for _, user := range found {
+		groupIDs := make([]string, 0, len(user.Groups))
+		for _, g := range user.Groups {
+			groupIDs = append(groupIDs, g.Value)
 		}
-		// All criteria match, so add user
-		ids = append(ids, guid)
-		logins = append(logins, user.LoginID)
-		emailAddresses = append(emailAddresses, user.EmailAddress)
+		ids = append(ids, user.ID)
+		logins = append(logins, user.UserName)
+		emailAddresses = append(emailAddresses, user.email())
+		users = append(users, map[string]interface{}{
+			"id":              user.ID,
+			"login":           user.UserName,
+			"email":           user.email(),
+			"first_name":      user.Name.GivenName,
+			"last_name":       user.Name.FamilyName,
+			"email_verified":  user.Extension.EmailVerified,
+			"disabled":        !user.Active,
+			"locked":          user.Extension.AccountStatus.AccountLocked,
+			"last_login_time": user.Extension.AccountStatus.LastLoginTime,
+			"group_ids":       groupIDs,
+		})
 	}
 	_ = d.Set("ids", ids)
 	_ = d.Set("logins", logins)
 	_ = d.Set("email_addresses", emailAddresses)
+	_ = d.Set("users", users)
+	_ = d.Set("truncated", truncated)
 	d.SetId(orgID)
 	return diags
 }
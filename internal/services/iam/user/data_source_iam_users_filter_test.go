@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserFilterSCIMFilter(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	yes := true
+	no := false
+
+	assert.Equal(t, `urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User:organization.value eq "org"`,
+		userFilter{OrganizationID: "org"}.scimFilter(now))
+
+	f := userFilter{
+		OrganizationID:     "org",
+		GroupID:            "group",
+		LoginPrefix:        `jane"`,
+		EmailPrefix:        "jane.doe@",
+		EmailVerified:      &yes,
+		Disabled:           &no,
+		Locked:             &yes,
+		LastLoginOlderThan: 90,
+	}
+	assert.Equal(t, `urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User:organization.value eq "org"`+
+		` and groups.value eq "group"`+
+		` and userName sw "jane\""`+
+		` and emails.value sw "jane.doe@"`+
+		` and urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User:emailVerified eq true`+
+		` and active eq true`+
+		` and urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User:accountStatus.accountLocked eq true`+
+		` and (urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User:accountStatus.lastLoginTime lt "2025-03-03T12:00:00Z"`+
+		` or not (urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User:accountStatus.lastLoginTime pr))`,
+		f.scimFilter(now))
+}
+
+func TestUserSearcher(t *testing.T) {
+	const total = 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" || r.URL.Query().Get("filter") != "f" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"detail":"denied"}`))
+			return
+		}
+		start, _ := strconv.Atoi(r.URL.Query().Get("startIndex"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		body := `{"totalResults":5,"Resources":[`
+		for i := start; i < start+count && i <= total; i++ {
+			if i > start {
+				body += ","
+			}
+			body += `{"id":"` + strconv.Itoa(i) + `","userName":"u` + strconv.Itoa(i) + `","active":true,` +
+				`"emails":[{"value":"other@example.com"},{"value":"u@example.com","primary":true}]}`
+		}
+		_, _ = w.Write([]byte(body + "]}"))
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	ctx := context.Background()
+
+	users, truncated, err := newUserSearcher(baseURL, "token", nil).search(ctx, "f", 2, 0)
+	assert.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Len(t, users, total)
+	assert.Equal(t, "u@example.com", users[0].email())
+
+	users, truncated, err = newUserSearcher(baseURL, "token", nil).search(ctx, "f", 2, 3)
+	assert.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Len(t, users, 3)
+
+	users, truncated, err = newUserSearcher(baseURL, "token", nil).search(ctx, "f", 10, 5)
+	assert.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Len(t, users, total)
+
+	_, _, err = newUserSearcher(baseURL, "wrong", nil).search(ctx, "f", 2, 0)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "denied")
+	}
+}
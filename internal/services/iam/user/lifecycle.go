@@ -0,0 +1,101 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/philips-software/go-dip-api/iam"
+)
+
+// customizeUserDiff rejects attempts to lock an account, which only IAM itself can do
+func customizeUserDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("locked") {
+		return nil
+	}
+	if _, locked := d.GetChange("locked"); locked.(bool) {
+		return fmt.Errorf("accounts cannot be locked by the provider, only unlocked: remove 'locked = true' or set it to false")
+	}
+	return nil
+}
+
+// lifecycleError describes a failed account operation. IAM can reject an operation without returning an error
+func lifecycleError(action, login string, ok bool, err error) error {
+	if err != nil {
+		return fmt.Errorf("%s user '%s': %w", action, login, err)
+	}
+	if !ok {
+		return fmt.Errorf("%s user '%s': request was not accepted by IAM", action, login)
+	}
+	return nil
+}
+
+// mfaActive returns true when the MFA status reported by IAM means MFA is turned on for the user
+func mfaActive(status string) bool {
+	switch strings.ToUpper(status) {
+	case "", "DISABLED", "INACTIVE", "NOT_ENABLED", "NOT_REQUIRED":
+		return false
+	}
+	return true
+}
+
+// updateUserLifecycle reconciles the account state of a user: disabled, locked
+// and the password and MFA reset triggers
+func updateUserLifecycle(client *iam.Client, d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	id := d.Id()
+	login := d.Get("login").(string)
+
+	if d.HasChange("disabled") {
+		var err error
+		if d.Get("disabled").(bool) {
+			_, _, err = client.Users.Disable(id)
+		} else {
+			_, _, err = client.Users.Enable(id)
+		}
+		if err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("changing disabled state of user '%s': %w", login, err))...)
+		}
+	}
+	if d.HasChange("locked") && !d.Get("locked").(bool) {
+		ok, _, err := client.Users.Unlock(id)
+		if err := lifecycleError("unlocking", login, ok, err); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	if d.HasChange("force_password_reset") && d.Get("force_password_reset").(string) != "" {
+		ok, _, err := client.Users.RecoverPassword(login)
+		if err := lifecycleError("triggering password reset for", login, ok, err); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+	if d.HasChange("mfa_reset") && d.Get("mfa_reset").(string) != "" {
+		user, _, err := client.Users.GetUserByID(id)
+		if err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("reading MFA status of user '%s': %w", login, err))...)
+		}
+		// Keep the old trigger value on failure, so the next apply retries the reset
+		d.Partial(true)
+		// Deactivating MFA removes the registered factors. It is only activated again
+		// when it was active before, so the user registers a new second factor on next login
+		ok, _, err := client.Users.SetMFA(id, false)
+		if err := lifecycleError("resetting MFA for", login, ok, err); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		if mfaActive(user.AccountStatus.MFAStatus) {
+			ok, _, err = client.Users.SetMFA(id, true)
+			if err := lifecycleError("re-activating MFA for", login, ok, err); err != nil {
+				return append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  err.Error(),
+					Detail:   "MFA is deactivated for the user. Apply again to retry the reset.",
+				})
+			}
+		}
+		d.Partial(false)
+	}
+	return diags
+}
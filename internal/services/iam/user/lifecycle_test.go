@@ -0,0 +1,30 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleError(t *testing.T) {
+	assert.Nil(t, lifecycleError("unlocking", "jdoe", true, nil))
+
+	err := lifecycleError("unlocking", "jdoe", false, nil)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "unlocking user 'jdoe': request was not accepted by IAM", err.Error())
+	}
+
+	cause := errors.New("forbidden")
+	err = lifecycleError("unlocking", "jdoe", false, cause)
+	assert.ErrorIs(t, err, cause)
+	assert.NotContains(t, err.Error(), "<nil>")
+}
+
+func TestMFAActive(t *testing.T) {
+	assert.False(t, mfaActive(""))
+	assert.False(t, mfaActive("DISABLED"))
+	assert.False(t, mfaActive("not_required"))
+	assert.True(t, mfaActive("ENABLED"))
+	assert.True(t, mfaActive("REGISTERED"))
+}
@@ -27,6 +27,7 @@ func ResourceIAMUser() *schema.Resource {
 			StateContext: importUserContext,
 		},
 
+		CustomizeDiff: customizeUserDiff,
 		CreateContext: resourceIAMUserCreate,
 		ReadContext:   resourceIAMUserRead,
 		UpdateContext: resourceIAMUserUpdate,
@@ -89,6 +90,28 @@ func ResourceIAMUser() *schema.Resource {
 				DiffSuppressFunc: tools.SuppressDefaultCommunicationChannel,
 				Description:      "Preferred communication channel. Email and SMS are supported channels. Email is the default channel if e-mail address is provided. Values supported: [ email | sms ].",
 			},
+			"disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Disables the user account while keeping the user and its audit history. When not set, the current state of the account is kept.",
+			},
+			"locked": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Reflects whether the account is locked, e.g. after too many failed login attempts. Set to false to unlock the account. Accounts cannot be locked through this field, setting it to true is rejected during plan.",
+			},
+			"force_password_reset": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value which, when changed, triggers a password reset email to the user.",
+			},
+			"mfa_reset": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value which, when changed, resets the MFA registration of the user so the second factor must be registered again.",
+			},
 			"access_status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -176,6 +199,12 @@ func resourceIAMUserCreate(ctx context.Context, d *schema.ResourceData, m interf
 		}
 	}
 	d.SetId(user.ID)
+	if d.Get("disabled").(bool) {
+		_, _, err = client.Users.Disable(user.ID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("disabling user '%s': %w", login, err))
+		}
+	}
 	return resourceIAMUserRead(ctx, d, m)
 }
 
@@ -224,6 +253,8 @@ func resourceIAMUserRead(_ context.Context, d *schema.ResourceData, m interface{
 		_ = d.Set("organization_id", user.ManagingOrganization)
 		_ = d.Set("preferred_communication_channel", user.PreferredCommunicationChannel)
 		_ = d.Set("preferred_language", user.PreferredLanguage)
+		_ = d.Set("disabled", user.AccountStatus.Disabled)
+		_ = d.Set("locked", user.AccountStatus.AccountLocked)
 	}
 	return diags
 }
@@ -268,6 +299,10 @@ func resourceIAMUserUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			return diag.FromErr(fmt.Errorf("resourceIAMUserUpdate LegacyUpdateUser: %w", err))
 		}
 	}
+	diags = append(diags, updateUserLifecycle(client, d)...)
+	if diags.HasError() {
+		return diags
+	}
 	if d.HasChange("password") {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
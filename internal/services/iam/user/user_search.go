@@ -0,0 +1,198 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/philips-software/terraform-provider-hsdp/internal/tools"
+)
+
+const (
+	scimUserExtension = "urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User"
+	scimAPIVersion    = "2"
+	userSearchTries   = 5
+)
+
+type userFilter struct {
+	OrganizationID     string
+	GroupID            string
+	LoginPrefix        string
+	EmailPrefix        string
+	EmailVerified      *bool
+	Disabled           *bool
+	Locked             *bool
+	LastLoginOlderThan int
+}
+
+// scimString quotes a value for use in a SCIM filter expression
+func scimString(value string) string {
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// scimFilter returns the SCIM filter expression which selects the users matching f
+func (f userFilter) scimFilter(now time.Time) string {
+	var terms []string
+	add := func(format string, args ...interface{}) {
+		terms = append(terms, fmt.Sprintf(format, args...))
+	}
+	add("%s:organization.value eq %s", scimUserExtension, scimString(f.OrganizationID))
+	if f.GroupID != "" {
+		add("groups.value eq %s", scimString(f.GroupID))
+	}
+	if f.LoginPrefix != "" {
+		add("userName sw %s", scimString(f.LoginPrefix))
+	}
+	if f.EmailPrefix != "" {
+		add("emails.value sw %s", scimString(f.EmailPrefix))
+	}
+	if f.EmailVerified != nil {
+		add("%s:emailVerified eq %t", scimUserExtension, *f.EmailVerified)
+	}
+	if f.Disabled != nil {
+		add("active eq %t", !*f.Disabled)
+	}
+	if f.Locked != nil {
+		add("%s:accountStatus.accountLocked eq %t", scimUserExtension, *f.Locked)
+	}
+	if f.LastLoginOlderThan > 0 {
+		lastLogin := scimUserExtension + ":accountStatus.lastLoginTime"
+		before := now.UTC().AddDate(0, 0, -f.LastLoginOlderThan).Format(time.RFC3339)
+		// Users who never logged in have no last login time
+		add("(%s lt %s or not (%s pr))", lastLogin, scimString(before), lastLogin)
+	}
+	return strings.Join(terms, " and ")
+}
+
+type scimUser struct {
+	ID       string `json:"id"`
+	UserName string `json:"userName"`
+	Name     struct {
+		GivenName  string `json:"givenName"`
+		FamilyName string `json:"familyName"`
+	} `json:"name"`
+	Emails []struct {
+		Value   string `json:"value"`
+		Primary bool   `json:"primary"`
+	} `json:"emails"`
+	Active bool `json:"active"`
+	Groups []struct {
+		Value string `json:"value"`
+	} `json:"groups"`
+	Extension struct {
+		EmailVerified bool `json:"emailVerified"`
+		AccountStatus struct {
+			AccountLocked bool   `json:"accountLocked"`
+			LastLoginTime string `json:"lastLoginTime"`
+		} `json:"accountStatus"`
+	} `json:"urn:ietf:params:scim:schemas:extension:philips:hsdp:2.0:User"`
+}
+
+// email returns the primary email address of the user
+func (u scimUser) email() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+type scimUserList struct {
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []scimUser `json:"Resources"`
+}
+
+// userSearcher queries the SCIM Users endpoint of IDM. It uses the HTTP client of the IAM client, so requests
+// go through the same transport and debug logging as the other IAM calls
+type userSearcher struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func newUserSearcher(baseIDMURL *url.URL, token string, httpClient *http.Client) *userSearcher {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &userSearcher{
+		baseURL:    strings.TrimSuffix(baseIDMURL.String(), "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// page returns the users matching filter, starting at the 1-based startIndex
+func (s *userSearcher) page(ctx context.Context, filter string, startIndex, count int) (*scimUserList, error) {
+	query := url.Values{}
+	query.Set("filter", filter)
+	query.Set("startIndex", strconv.Itoa(startIndex))
+	query.Set("count", strconv.Itoa(count))
+
+	var list scimUserList
+	err := tools.TryHTTPCall(ctx, userSearchTries, func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/authorize/scim/v2/Users?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+s.token)
+		req.Header.Set("Accept", "application/scim+json")
+		req.Header.Set("Api-Version", scimAPIVersion)
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("searching users: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			var scimError struct {
+				Detail string `json:"detail"`
+			}
+			_ = json.NewDecoder(resp.Body).Decode(&scimError)
+			return resp, fmt.Errorf("searching users failed with status %d: %s", resp.StatusCode, scimError.Detail)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+			return resp, fmt.Errorf("decoding user search response: %w", err)
+		}
+		return resp, nil
+	}, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// search pages through the users matching filter. It stops after maxResults users when maxResults is
+// positive and reports whether more users matched
+func (s *userSearcher) search(ctx context.Context, filter string, pageSize, maxResults int) ([]scimUser, bool, error) {
+	var users []scimUser
+	for startIndex := 1; ; {
+		count := pageSize
+		if maxResults > 0 && maxResults-len(users) < count {
+			count = maxResults - len(users)
+		}
+		list, err := s.page(ctx, filter, startIndex, count)
+		if err != nil {
+			return nil, false, err
+		}
+		users = append(users, list.Resources...)
+		startIndex += len(list.Resources)
+		more := len(list.Resources) > 0 && startIndex <= list.TotalResults
+		if !more {
+			return users, false, nil
+		}
+		if maxResults > 0 && len(users) >= maxResults {
+			return users, true, nil
+		}
+	}
+}